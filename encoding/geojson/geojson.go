@@ -0,0 +1,100 @@
+// Package geojson wraps geometry.Geometry's own GeoJSON marshaling (see
+// geometry/geojson.go) in the Feature and FeatureCollection envelopes
+// defined by RFC 7946. It only ever imports geometry, never the reverse,
+// so it cannot add the MarshalJSON/UnmarshalJSON methods itself -- those
+// have to live next to the types they describe.
+package geojson
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/MathewBravo/gospatial/geometry"
+)
+
+// Feature is a GeoJSON Feature: a geometry plus a free-form properties
+// bag and an optional id.
+type Feature struct {
+	ID         interface{}
+	Geometry   geometry.Geometry
+	Properties map[string]interface{}
+}
+
+type featureJSON struct {
+	Type       string                 `json:"type"`
+	ID         interface{}            `json:"id,omitempty"`
+	Geometry   json.RawMessage        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+func (f Feature) MarshalJSON() ([]byte, error) {
+	var geomJSON json.RawMessage
+	if f.Geometry != nil {
+		m, ok := f.Geometry.(json.Marshaler)
+		if !ok {
+			return nil, fmt.Errorf("geojson: %w: feature geometry does not support GeoJSON marshaling", geometry.ErrUnsupported)
+		}
+		b, err := m.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		geomJSON = b
+	}
+	properties := f.Properties
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	return json.Marshal(featureJSON{Type: "Feature", ID: f.ID, Geometry: geomJSON, Properties: properties})
+}
+
+func (f *Feature) UnmarshalJSON(data []byte) error {
+	var raw featureJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Type != "Feature" {
+		return fmt.Errorf("geojson: %w: expected GeoJSON type \"Feature\", got %q", geometry.ErrMalformed, raw.Type)
+	}
+	var geom geometry.Geometry
+	if len(raw.Geometry) > 0 && string(raw.Geometry) != "null" {
+		g, err := geometry.FromGeoJSON(raw.Geometry)
+		if err != nil {
+			return err
+		}
+		geom = g
+	}
+	f.ID = raw.ID
+	f.Geometry = geom
+	f.Properties = raw.Properties
+	return nil
+}
+
+// FeatureCollection is a GeoJSON FeatureCollection.
+type FeatureCollection struct {
+	Features []Feature
+}
+
+type featureCollectionJSON struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+func (fc FeatureCollection) MarshalJSON() ([]byte, error) {
+	features := fc.Features
+	if features == nil {
+		features = []Feature{}
+	}
+	return json.Marshal(featureCollectionJSON{Type: "FeatureCollection", Features: features})
+}
+
+func (fc *FeatureCollection) UnmarshalJSON(data []byte) error {
+	var raw featureCollectionJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Type != "FeatureCollection" {
+		return fmt.Errorf("geojson: %w: expected GeoJSON type \"FeatureCollection\", got %q", geometry.ErrMalformed, raw.Type)
+	}
+	fc.Features = raw.Features
+	return nil
+}
@@ -0,0 +1,63 @@
+package geojson
+
+import (
+	"testing"
+
+	"github.com/MathewBravo/gospatial/geometry"
+)
+
+func TestFeatureRoundTrip(t *testing.T) {
+	f := Feature{
+		ID:         "abc",
+		Geometry:   geometry.NewPoint(1, 2),
+		Properties: map[string]interface{}{"name": "test"},
+	}
+	data, err := f.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var back Feature
+	if err := back.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	pt, ok := back.Geometry.(*geometry.Point)
+	if !ok {
+		t.Fatalf("expected *geometry.Point, got %T", back.Geometry)
+	}
+	if pt.X != 1 || pt.Y != 2 {
+		t.Errorf("geometry = %+v, want (1, 2)", pt.Coordinate)
+	}
+	if back.Properties["name"] != "test" {
+		t.Errorf("properties[name] = %v, want \"test\"", back.Properties["name"])
+	}
+}
+
+func TestFeatureCollectionRoundTrip(t *testing.T) {
+	fc := FeatureCollection{
+		Features: []Feature{
+			{Geometry: geometry.NewPoint(0, 0)},
+			{Geometry: geometry.NewPoint(1, 1)},
+		},
+	}
+	data, err := fc.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var back FeatureCollection
+	if err := back.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	if len(back.Features) != 2 {
+		t.Fatalf("got %d features, want 2", len(back.Features))
+	}
+}
+
+func TestFeatureCollectionRejectsWrongType(t *testing.T) {
+	var fc FeatureCollection
+	err := fc.UnmarshalJSON([]byte(`{"type":"Feature","geometry":null,"properties":{}}`))
+	if err == nil {
+		t.Errorf("expected error unmarshaling a Feature as a FeatureCollection")
+	}
+}
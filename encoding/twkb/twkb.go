@@ -0,0 +1,761 @@
+// Package twkb implements TWKB (Tiny Well-Known Binary), a compact,
+// varint/zigzag-delta-encoded binary geometry format designed for mobile
+// and web transport.
+//
+// Supported: Point, LineString, Polygon, MultiPoint, MultiLineString,
+// MultiPolygon and GeometryCollection, plus the Z ordinate on
+// geometry.PointZ/LineStringZ/PolygonZ (there is no M ordinate anywhere
+// in the geometry package, so this codec does not support one either).
+// Precision (decimal digits retained per ordinate) is configurable
+// per-call via Options, independently for xy and z, and round-trips
+// losslessly at the declared precision. The optional bounding-box and
+// size headers from the TWKB spec are both supported.
+//
+// One deliberate divergence from the reference TWKB spec: real-world
+// encoders always treat a polygon ring as implicitly closed and never
+// write its duplicated closing vertex. geometry.Polygon does not
+// guarantee its rings are one or the other (see its doc comment), so
+// this package writes exactly the points a ring carries, closing
+// duplicate included or not, and decodes back to exactly that. Output
+// from this package is therefore not guaranteed byte-identical to
+// another TWKB encoder's output for the same geometry, but it round-trips
+// losslessly through Marshal/Unmarshal.
+package twkb
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/MathewBravo/gospatial/geometry"
+)
+
+// Options configures the precision and optional headers used by Marshal.
+type Options struct {
+	// Precision is the number of decimal digits retained for X and Y.
+	Precision int
+	// ZPrecision is the number of decimal digits retained for Z, when the
+	// input geometry carries one.
+	ZPrecision int
+	// IncludeBBox writes an absolute (not delta-encoded) bounding box
+	// header ahead of the coordinate data.
+	IncludeBBox bool
+	// IncludeSize writes the encoded body's byte length as a header,
+	// letting a reader skip an unwanted geometry without fully decoding it.
+	IncludeSize bool
+}
+
+// DefaultOptions returns the TWKB spec's conventional default precision
+// (7 decimal digits for both xy and z) with no optional headers.
+func DefaultOptions() Options {
+	return Options{Precision: 7, ZPrecision: 7}
+}
+
+const (
+	typePoint              = 1
+	typeLineString         = 2
+	typePolygon            = 3
+	typeMultiPoint         = 4
+	typeMultiLineString    = 5
+	typeMultiPolygon       = 6
+	typeGeometryCollection = 7
+)
+
+// --- varint / zigzag ---
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			b |= 0x80
+		}
+		buf = append(buf, b)
+		if v == 0 {
+			return buf
+		}
+	}
+}
+
+func readUvarint(b []byte, pos *int) (uint64, error) {
+	var result uint64
+	var shift uint
+	for {
+		if *pos >= len(b) {
+			return 0, fmt.Errorf("twkb: %w: unexpected end of input while reading a varint", geometry.ErrMalformed)
+		}
+		byt := b[*pos]
+		*pos++
+		result |= uint64(byt&0x7f) << shift
+		if byt&0x80 == 0 {
+			return result, nil
+		}
+		shift += 7
+	}
+}
+
+func zigzagEncode(v int64) uint64 { return uint64((v << 1) ^ (v >> 63)) }
+func zigzagDecode(v uint64) int64 { return int64(v>>1) ^ -int64(v&1) }
+
+func appendZigzag(buf []byte, v int64) []byte { return appendUvarint(buf, zigzagEncode(v)) }
+
+func readZigzag(b []byte, pos *int) (int64, error) {
+	v, err := readUvarint(b, pos)
+	if err != nil {
+		return 0, err
+	}
+	return zigzagDecode(v), nil
+}
+
+// --- header byte helpers ---
+
+// packPrecision zigzag-encodes a small signed precision value into the 4
+// bits TWKB's type-and-precision byte reserves for it.
+func packPrecision(precision int) byte {
+	return byte(zigzagEncode(int64(precision))) & 0x0f
+}
+
+func unpackPrecision(nibble byte) int {
+	return int(zigzagDecode(uint64(nibble & 0x0f)))
+}
+
+// --- scaling ---
+
+type scaler struct {
+	xyScale float64
+	zScale  float64
+}
+
+func (s scaler) toFixed(v float64) int64    { return int64(math.Round(v * s.xyScale)) }
+func (s scaler) toFixedZ(v float64) int64   { return int64(math.Round(v * s.zScale)) }
+func (s scaler) fromFixed(v int64) float64  { return float64(v) / s.xyScale }
+func (s scaler) fromFixedZ(v int64) float64 { return float64(v) / s.zScale }
+
+// cursor tracks the running absolute position the next coordinate's
+// zigzag delta is relative to; TWKB's delta chain runs across an entire
+// top-level geometry (every ring/member of a Polygon/Multi*), so one
+// cursor is threaded through that geometry's whole write. A
+// GeometryCollection member is encoded by its own Marshal call and so
+// starts from a fresh cursor.
+type cursor struct {
+	x, y, z int64
+}
+
+// --- geometry introspection ---
+
+// geomInfo reports the TWKB type code and whether g carries a Z ordinate.
+func geomInfo(g geometry.Geometry) (typeCode int, hasZ bool, err error) {
+	switch g.(type) {
+	case *geometry.Point:
+		return typePoint, false, nil
+	case *geometry.PointZ:
+		return typePoint, true, nil
+	case *geometry.LineString:
+		return typeLineString, false, nil
+	case *geometry.LineStringZ:
+		return typeLineString, true, nil
+	case *geometry.Polygon:
+		return typePolygon, false, nil
+	case *geometry.PolygonZ:
+		return typePolygon, true, nil
+	case *geometry.MultiPoint:
+		return typeMultiPoint, false, nil
+	case *geometry.MultiLineString:
+		return typeMultiLineString, false, nil
+	case *geometry.MultiPolygon:
+		return typeMultiPolygon, false, nil
+	case *geometry.GeometryCollection:
+		return typeGeometryCollection, false, nil
+	default:
+		return 0, false, fmt.Errorf("twkb: %w: unsupported geometry type %T", geometry.ErrUnsupported, g)
+	}
+}
+
+// flatCoordinates returns every coordinate g is built from, in the exact
+// order the body writer will visit them, for bounding-box computation.
+func flatCoordinates(g geometry.Geometry) (xy []geometry.Coordinate, z []float64, err error) {
+	switch v := g.(type) {
+	case *geometry.Point:
+		if !v.Empty {
+			xy = append(xy, v.Coordinate)
+		}
+	case *geometry.PointZ:
+		if !v.Empty {
+			xy = append(xy, geometry.Coordinate{X: v.X, Y: v.Y})
+			z = append(z, v.Z)
+		}
+	case *geometry.LineString:
+		xy = append(xy, v.Points...)
+	case *geometry.LineStringZ:
+		for _, c := range v.Points {
+			xy = append(xy, geometry.Coordinate{X: c.X, Y: c.Y})
+			z = append(z, c.Z)
+		}
+	case *geometry.Polygon:
+		xy = append(xy, v.Shell...)
+		for _, h := range v.Holes {
+			xy = append(xy, h...)
+		}
+	case *geometry.PolygonZ:
+		for _, c := range v.Shell {
+			xy = append(xy, geometry.Coordinate{X: c.X, Y: c.Y})
+			z = append(z, c.Z)
+		}
+		for _, h := range v.Holes {
+			for _, c := range h {
+				xy = append(xy, geometry.Coordinate{X: c.X, Y: c.Y})
+				z = append(z, c.Z)
+			}
+		}
+	case *geometry.MultiPoint:
+		for _, p := range v.Points {
+			sub, _, err := flatCoordinates(p)
+			if err != nil {
+				return nil, nil, err
+			}
+			xy = append(xy, sub...)
+		}
+	case *geometry.MultiLineString:
+		for _, l := range v.Lines {
+			sub, _, err := flatCoordinates(l)
+			if err != nil {
+				return nil, nil, err
+			}
+			xy = append(xy, sub...)
+		}
+	case *geometry.MultiPolygon:
+		for _, pg := range v.Polygons {
+			sub, _, err := flatCoordinates(pg)
+			if err != nil {
+				return nil, nil, err
+			}
+			xy = append(xy, sub...)
+		}
+	case *geometry.GeometryCollection:
+		for _, sub := range v.Geometries {
+			pts, zs, err := flatCoordinates(sub)
+			if err != nil {
+				return nil, nil, err
+			}
+			xy = append(xy, pts...)
+			z = append(z, zs...)
+		}
+	default:
+		return nil, nil, fmt.Errorf("twkb: %w: unsupported geometry type %T", geometry.ErrUnsupported, g)
+	}
+	return xy, z, nil
+}
+
+func isEmpty(g geometry.Geometry) bool {
+	switch v := g.(type) {
+	case *geometry.Point:
+		return v.Empty
+	case *geometry.PointZ:
+		return v.Empty
+	case *geometry.LineString:
+		return len(v.Points) == 0
+	case *geometry.LineStringZ:
+		return len(v.Points) == 0
+	case *geometry.Polygon:
+		return len(v.Shell) == 0
+	case *geometry.PolygonZ:
+		return len(v.Shell) == 0
+	case *geometry.MultiPoint:
+		return len(v.Points) == 0
+	case *geometry.MultiLineString:
+		return len(v.Lines) == 0
+	case *geometry.MultiPolygon:
+		return len(v.Polygons) == 0
+	case *geometry.GeometryCollection:
+		return len(v.Geometries) == 0
+	default:
+		return false
+	}
+}
+
+// Marshal encodes g as TWKB per opts.
+func Marshal(g geometry.Geometry, opts Options) ([]byte, error) {
+	typeCode, hasZ, err := geomInfo(g)
+	if err != nil {
+		return nil, err
+	}
+
+	header := []byte{byte(typeCode&0x0f) | packPrecision(opts.Precision)<<4}
+
+	var metadata byte
+	empty := isEmpty(g)
+	if empty {
+		metadata |= 1 << 4
+	}
+	if hasZ {
+		metadata |= 1 << 3
+	}
+	if opts.IncludeBBox && !empty {
+		metadata |= 1 << 0
+	}
+	if opts.IncludeSize {
+		metadata |= 1 << 1
+	}
+	header = append(header, metadata)
+	if hasZ {
+		header = append(header, packPrecision(opts.ZPrecision))
+	}
+
+	if empty {
+		if opts.IncludeSize {
+			sized := appendUvarint(nil, 0)
+			header = append(header, sized...)
+		}
+		return header, nil
+	}
+
+	s := scaler{xyScale: math.Pow10(opts.Precision), zScale: math.Pow10(opts.ZPrecision)}
+
+	if opts.IncludeBBox {
+		xy, z, err := flatCoordinates(g)
+		if err != nil {
+			return nil, err
+		}
+		header = appendBBox(header, xy, z, hasZ, s)
+	}
+
+	var body []byte
+	c := &cursor{}
+	body, err = writeGeometryBody(body, g, typeCode, hasZ, s, c, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeSize {
+		header = append(header, appendUvarint(nil, uint64(len(body)))...)
+	}
+	return append(header, body...), nil
+}
+
+func appendBBox(header []byte, xy []geometry.Coordinate, z []float64, hasZ bool, s scaler) []byte {
+	minX, minY := xy[0].X, xy[0].Y
+	maxX, maxY := minX, minY
+	for _, c := range xy[1:] {
+		minX, maxX = math.Min(minX, c.X), math.Max(maxX, c.X)
+		minY, maxY = math.Min(minY, c.Y), math.Max(maxY, c.Y)
+	}
+	minXf, maxXf := s.toFixed(minX), s.toFixed(maxX)
+	minYf, maxYf := s.toFixed(minY), s.toFixed(maxY)
+	header = appendZigzag(header, minXf)
+	header = appendZigzag(header, maxXf-minXf)
+	header = appendZigzag(header, minYf)
+	header = appendZigzag(header, maxYf-minYf)
+	if hasZ && len(z) > 0 {
+		minZ, maxZ := z[0], z[0]
+		for _, v := range z[1:] {
+			minZ, maxZ = math.Min(minZ, v), math.Max(maxZ, v)
+		}
+		minZf, maxZf := s.toFixedZ(minZ), s.toFixedZ(maxZ)
+		header = appendZigzag(header, minZf)
+		header = appendZigzag(header, maxZf-minZf)
+	}
+	return header
+}
+
+func writeCoordinate2D(body []byte, c geometry.Coordinate, s scaler, cur *cursor) []byte {
+	x, y := s.toFixed(c.X), s.toFixed(c.Y)
+	body = appendZigzag(body, x-cur.x)
+	body = appendZigzag(body, y-cur.y)
+	cur.x, cur.y = x, y
+	return body
+}
+
+func writeCoordinateXYZ(body []byte, c geometry.CoordinateXYZ, s scaler, cur *cursor) []byte {
+	x, y, z := s.toFixed(c.X), s.toFixed(c.Y), s.toFixedZ(c.Z)
+	body = appendZigzag(body, x-cur.x)
+	body = appendZigzag(body, y-cur.y)
+	body = appendZigzag(body, z-cur.z)
+	cur.x, cur.y, cur.z = x, y, z
+	return body
+}
+
+func writeRing2D(body []byte, ring []geometry.Coordinate, s scaler, cur *cursor) []byte {
+	body = appendUvarint(body, uint64(len(ring)))
+	for _, c := range ring {
+		body = writeCoordinate2D(body, c, s, cur)
+	}
+	return body
+}
+
+func writeRingXYZ(body []byte, ring []geometry.CoordinateXYZ, s scaler, cur *cursor) []byte {
+	body = appendUvarint(body, uint64(len(ring)))
+	for _, c := range ring {
+		body = writeCoordinateXYZ(body, c, s, cur)
+	}
+	return body
+}
+
+// writeGeometryBody writes just the coordinate payload of g (no
+// type/precision/metadata header), recursing for Multi*/GeometryCollection.
+// A nested member of a Multi* type shares the running cursor with its
+// parent (a single delta chain for the whole geometry); a member of a
+// GeometryCollection is fully self-contained with its own header and its
+// own cursor, per the TWKB spec.
+func writeGeometryBody(body []byte, g geometry.Geometry, typeCode int, hasZ bool, s scaler, cur *cursor, opts Options) ([]byte, error) {
+	switch v := g.(type) {
+	case *geometry.Point:
+		return writeCoordinate2D(body, v.Coordinate, s, cur), nil
+	case *geometry.PointZ:
+		return writeCoordinateXYZ(body, v.CoordinateXYZ, s, cur), nil
+	case *geometry.LineString:
+		return writeRing2D(body, v.Points, s, cur), nil
+	case *geometry.LineStringZ:
+		return writeRingXYZ(body, v.Points, s, cur), nil
+	case *geometry.Polygon:
+		rings := append([][]geometry.Coordinate{v.Shell}, v.Holes...)
+		body = appendUvarint(body, uint64(len(rings)))
+		for _, r := range rings {
+			body = writeRing2D(body, r, s, cur)
+		}
+		return body, nil
+	case *geometry.PolygonZ:
+		rings := append([][]geometry.CoordinateXYZ{v.Shell}, v.Holes...)
+		body = appendUvarint(body, uint64(len(rings)))
+		for _, r := range rings {
+			body = writeRingXYZ(body, r, s, cur)
+		}
+		return body, nil
+	case *geometry.MultiPoint:
+		body = appendUvarint(body, uint64(len(v.Points)))
+		for _, p := range v.Points {
+			body = writeCoordinate2D(body, p.Coordinate, s, cur)
+		}
+		return body, nil
+	case *geometry.MultiLineString:
+		body = appendUvarint(body, uint64(len(v.Lines)))
+		for _, l := range v.Lines {
+			body = writeRing2D(body, l.Points, s, cur)
+		}
+		return body, nil
+	case *geometry.MultiPolygon:
+		body = appendUvarint(body, uint64(len(v.Polygons)))
+		for _, pg := range v.Polygons {
+			rings := append([][]geometry.Coordinate{pg.Shell}, pg.Holes...)
+			body = appendUvarint(body, uint64(len(rings)))
+			for _, r := range rings {
+				body = writeRing2D(body, r, s, cur)
+			}
+		}
+		return body, nil
+	case *geometry.GeometryCollection:
+		body = appendUvarint(body, uint64(len(v.Geometries)))
+		for _, sub := range v.Geometries {
+			encoded, err := Marshal(sub, opts)
+			if err != nil {
+				return nil, err
+			}
+			body = append(body, encoded...)
+		}
+		return body, nil
+	default:
+		return nil, fmt.Errorf("twkb: %w: unsupported geometry type %T", geometry.ErrUnsupported, g)
+	}
+}
+
+// Unmarshal decodes a TWKB-encoded geometry.
+func Unmarshal(b []byte) (geometry.Geometry, error) {
+	g, _, err := unmarshalAt(b, 0)
+	return g, err
+}
+
+func unmarshalAt(b []byte, pos int) (geometry.Geometry, int, error) {
+	if pos >= len(b) {
+		return nil, pos, fmt.Errorf("twkb: %w: unexpected end of input reading header", geometry.ErrMalformed)
+	}
+	first := b[pos]
+	pos++
+	typeCode := int(first & 0x0f)
+	precision := unpackPrecision(first >> 4)
+
+	if pos >= len(b) {
+		return nil, pos, fmt.Errorf("twkb: %w: unexpected end of input reading metadata", geometry.ErrMalformed)
+	}
+	metadata := b[pos]
+	pos++
+	empty := metadata&(1<<4) != 0
+	hasZ := metadata&(1<<3) != 0
+	hasBBox := metadata&(1<<0) != 0
+	hasSize := metadata&(1<<1) != 0
+
+	zPrecision := 0
+	if hasZ {
+		if pos >= len(b) {
+			return nil, pos, fmt.Errorf("twkb: %w: unexpected end of input reading extended precision", geometry.ErrMalformed)
+		}
+		zPrecision = unpackPrecision(b[pos])
+		pos++
+	}
+
+	s := scaler{xyScale: math.Pow10(precision), zScale: math.Pow10(zPrecision)}
+
+	if empty {
+		if hasSize {
+			if _, err := readUvarint(b, &pos); err != nil {
+				return nil, pos, err
+			}
+		}
+		g, err := emptyGeometry(typeCode, hasZ)
+		return g, pos, err
+	}
+
+	if hasBBox {
+		n := 2
+		if hasZ {
+			n = 3
+		}
+		for i := 0; i < n; i++ {
+			if _, err := readZigzag(b, &pos); err != nil {
+				return nil, pos, err
+			}
+			if _, err := readZigzag(b, &pos); err != nil {
+				return nil, pos, err
+			}
+		}
+	}
+
+	if hasSize {
+		if _, err := readUvarint(b, &pos); err != nil {
+			return nil, pos, err
+		}
+	}
+
+	cur := &cursor{}
+	g, pos, err := readGeometryBody(b, pos, typeCode, hasZ, s, cur)
+	if err != nil {
+		return nil, pos, err
+	}
+	return g, pos, nil
+}
+
+func emptyGeometry(typeCode int, hasZ bool) (geometry.Geometry, error) {
+	switch typeCode {
+	case typePoint:
+		if hasZ {
+			return geometry.NewEmptyPointZ(), nil
+		}
+		return geometry.NewEmptyPoint(), nil
+	case typeLineString:
+		if hasZ {
+			return geometry.NewLineStringZ(nil), nil
+		}
+		return geometry.NewLineString(nil), nil
+	case typePolygon:
+		if hasZ {
+			return geometry.NewPolygonZ(nil, nil), nil
+		}
+		return geometry.NewPolygon(nil, nil), nil
+	case typeMultiPoint:
+		return geometry.NewMultiPoint(nil), nil
+	case typeMultiLineString:
+		return geometry.NewMultiLineString(nil), nil
+	case typeMultiPolygon:
+		return geometry.NewMultiPolygon(nil), nil
+	case typeGeometryCollection:
+		return geometry.NewGeometryCollection(nil), nil
+	default:
+		return nil, fmt.Errorf("twkb: %w: unknown TWKB type code %d", geometry.ErrMalformed, typeCode)
+	}
+}
+
+func readCoordinate2D(b []byte, pos int, s scaler, cur *cursor) (geometry.Coordinate, int, error) {
+	dx, err := readZigzag(b, &pos)
+	if err != nil {
+		return geometry.Coordinate{}, pos, err
+	}
+	dy, err := readZigzag(b, &pos)
+	if err != nil {
+		return geometry.Coordinate{}, pos, err
+	}
+	cur.x += dx
+	cur.y += dy
+	return geometry.Coordinate{X: s.fromFixed(cur.x), Y: s.fromFixed(cur.y)}, pos, nil
+}
+
+func readCoordinateXYZ(b []byte, pos int, s scaler, cur *cursor) (geometry.CoordinateXYZ, int, error) {
+	dx, err := readZigzag(b, &pos)
+	if err != nil {
+		return geometry.CoordinateXYZ{}, pos, err
+	}
+	dy, err := readZigzag(b, &pos)
+	if err != nil {
+		return geometry.CoordinateXYZ{}, pos, err
+	}
+	dz, err := readZigzag(b, &pos)
+	if err != nil {
+		return geometry.CoordinateXYZ{}, pos, err
+	}
+	cur.x += dx
+	cur.y += dy
+	cur.z += dz
+	return geometry.CoordinateXYZ{X: s.fromFixed(cur.x), Y: s.fromFixed(cur.y), Z: s.fromFixedZ(cur.z)}, pos, nil
+}
+
+func readRing2D(b []byte, pos int, s scaler, cur *cursor) ([]geometry.Coordinate, int, error) {
+	n, err := readUvarint(b, &pos)
+	if err != nil {
+		return nil, pos, err
+	}
+	ring := make([]geometry.Coordinate, n)
+	for i := range ring {
+		c, next, err := readCoordinate2D(b, pos, s, cur)
+		if err != nil {
+			return nil, next, err
+		}
+		ring[i] = c
+		pos = next
+	}
+	return ring, pos, nil
+}
+
+func readRingXYZ(b []byte, pos int, s scaler, cur *cursor) ([]geometry.CoordinateXYZ, int, error) {
+	n, err := readUvarint(b, &pos)
+	if err != nil {
+		return nil, pos, err
+	}
+	ring := make([]geometry.CoordinateXYZ, n)
+	for i := range ring {
+		c, next, err := readCoordinateXYZ(b, pos, s, cur)
+		if err != nil {
+			return nil, next, err
+		}
+		ring[i] = c
+		pos = next
+	}
+	return ring, pos, nil
+}
+
+func readGeometryBody(b []byte, pos int, typeCode int, hasZ bool, s scaler, cur *cursor) (geometry.Geometry, int, error) {
+	switch typeCode {
+	case typePoint:
+		if hasZ {
+			c, next, err := readCoordinateXYZ(b, pos, s, cur)
+			if err != nil {
+				return nil, next, err
+			}
+			return geometry.NewPointZ(c.X, c.Y, c.Z), next, nil
+		}
+		c, next, err := readCoordinate2D(b, pos, s, cur)
+		if err != nil {
+			return nil, next, err
+		}
+		return geometry.NewPoint(c.X, c.Y), next, nil
+	case typeLineString:
+		if hasZ {
+			pts, next, err := readRingXYZ(b, pos, s, cur)
+			if err != nil {
+				return nil, next, err
+			}
+			return geometry.NewLineStringZ(pts), next, nil
+		}
+		pts, next, err := readRing2D(b, pos, s, cur)
+		if err != nil {
+			return nil, next, err
+		}
+		return geometry.NewLineString(pts), next, nil
+	case typePolygon:
+		n, err := readUvarint(b, &pos)
+		if err != nil {
+			return nil, pos, err
+		}
+		if hasZ {
+			rings := make([][]geometry.CoordinateXYZ, n)
+			for i := range rings {
+				r, next, err := readRingXYZ(b, pos, s, cur)
+				if err != nil {
+					return nil, next, err
+				}
+				rings[i], pos = r, next
+			}
+			if len(rings) == 0 {
+				return geometry.NewPolygonZ(nil, nil), pos, nil
+			}
+			return geometry.NewPolygonZ(rings[0], rings[1:]), pos, nil
+		}
+		rings := make([][]geometry.Coordinate, n)
+		for i := range rings {
+			r, next, err := readRing2D(b, pos, s, cur)
+			if err != nil {
+				return nil, next, err
+			}
+			rings[i], pos = r, next
+		}
+		if len(rings) == 0 {
+			return geometry.NewPolygon(nil, nil), pos, nil
+		}
+		return geometry.NewPolygon(rings[0], rings[1:]), pos, nil
+	case typeMultiPoint:
+		n, err := readUvarint(b, &pos)
+		if err != nil {
+			return nil, pos, err
+		}
+		points := make([]*geometry.Point, n)
+		for i := range points {
+			c, next, err := readCoordinate2D(b, pos, s, cur)
+			if err != nil {
+				return nil, next, err
+			}
+			points[i], pos = geometry.NewPoint(c.X, c.Y), next
+		}
+		return geometry.NewMultiPoint(points), pos, nil
+	case typeMultiLineString:
+		n, err := readUvarint(b, &pos)
+		if err != nil {
+			return nil, pos, err
+		}
+		lines := make([]*geometry.LineString, n)
+		for i := range lines {
+			pts, next, err := readRing2D(b, pos, s, cur)
+			if err != nil {
+				return nil, next, err
+			}
+			lines[i], pos = geometry.NewLineString(pts), next
+		}
+		return geometry.NewMultiLineString(lines), pos, nil
+	case typeMultiPolygon:
+		n, err := readUvarint(b, &pos)
+		if err != nil {
+			return nil, pos, err
+		}
+		polys := make([]*geometry.Polygon, n)
+		for i := range polys {
+			numRings, err := readUvarint(b, &pos)
+			if err != nil {
+				return nil, pos, err
+			}
+			rings := make([][]geometry.Coordinate, numRings)
+			for j := range rings {
+				r, next, err := readRing2D(b, pos, s, cur)
+				if err != nil {
+					return nil, next, err
+				}
+				rings[j], pos = r, next
+			}
+			if len(rings) == 0 {
+				polys[i] = geometry.NewPolygon(nil, nil)
+			} else {
+				polys[i] = geometry.NewPolygon(rings[0], rings[1:])
+			}
+		}
+		return geometry.NewMultiPolygon(polys), pos, nil
+	case typeGeometryCollection:
+		n, err := readUvarint(b, &pos)
+		if err != nil {
+			return nil, pos, err
+		}
+		geoms := make([]geometry.Geometry, n)
+		for i := range geoms {
+			sub, next, err := unmarshalAt(b, pos)
+			if err != nil {
+				return nil, next, err
+			}
+			geoms[i], pos = sub, next
+		}
+		return geometry.NewGeometryCollection(geoms), pos, nil
+	default:
+		return nil, pos, fmt.Errorf("twkb: %w: unknown TWKB type code %d", geometry.ErrMalformed, typeCode)
+	}
+}
@@ -0,0 +1,119 @@
+package twkb
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MathewBravo/gospatial/geometry"
+)
+
+func assertWKTRoundTrip(t *testing.T, wkt string, opts Options) {
+	t.Helper()
+	g, err := geometry.FromWKT(wkt)
+	if err != nil {
+		t.Fatalf("FromWKT(%q): %v", wkt, err)
+	}
+	data, err := Marshal(g, opts)
+	if err != nil {
+		t.Fatalf("Marshal(%q): %v", wkt, err)
+	}
+	back, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal(%q): %v", wkt, err)
+	}
+	gotWKT, err := back.AsText()
+	if err != nil {
+		t.Fatalf("AsText after TWKB round trip for %q: %v", wkt, err)
+	}
+	if gotWKT != wkt {
+		t.Errorf("TWKB round trip mismatch: got %q, want %q", gotWKT, wkt)
+	}
+}
+
+func TestTWKBRoundTrip(t *testing.T) {
+	cases := []string{
+		"POINT (1 2)",
+		"LINESTRING (0 0, 1 1, 2 2)",
+		"POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))",
+		"MULTIPOINT ((0 0), (1 1))",
+		"MULTILINESTRING ((0 0, 1 1), (2 2, 3 3))",
+		"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)))",
+		"GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (0 0, 1 1))",
+	}
+	for _, wkt := range cases {
+		assertWKTRoundTrip(t, wkt, DefaultOptions())
+	}
+}
+
+func TestTWKBRoundTripWithHeaders(t *testing.T) {
+	opts := DefaultOptions()
+	opts.IncludeBBox = true
+	opts.IncludeSize = true
+	assertWKTRoundTrip(t, "LINESTRING (0 0, 1 1, 2 2)", opts)
+}
+
+func TestTWKBPrecisionRounding(t *testing.T) {
+	g := geometry.NewPoint(1.23456789, 9.87654321)
+	opts := Options{Precision: 2}
+	data, err := Marshal(g, opts)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	back, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	pt, ok := back.(*geometry.Point)
+	if !ok {
+		t.Fatalf("expected *geometry.Point, got %T", back)
+	}
+	if math.Abs(pt.X-1.23) > 1e-9 || math.Abs(pt.Y-9.88) > 1e-9 {
+		t.Errorf("got (%v, %v), want (1.23, 9.88) after rounding to 2 decimal digits", pt.X, pt.Y)
+	}
+}
+
+func TestTWKBEmptyGeometry(t *testing.T) {
+	g := geometry.NewEmptyPoint()
+	data, err := Marshal(g, DefaultOptions())
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	back, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	pt, ok := back.(*geometry.Point)
+	if !ok {
+		t.Fatalf("expected *geometry.Point, got %T", back)
+	}
+	if !pt.Empty {
+		t.Errorf("expected round-tripped point to still be Empty")
+	}
+}
+
+func TestTWKBPointZRoundTrip(t *testing.T) {
+	g := geometry.NewPointZ(1, 2, 3)
+	data, err := Marshal(g, DefaultOptions())
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	back, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	pz, ok := back.(*geometry.PointZ)
+	if !ok {
+		t.Fatalf("expected *geometry.PointZ, got %T", back)
+	}
+	if pz.X != 1 || pz.Y != 2 || pz.Z != 3 {
+		t.Errorf("got %+v, want (1, 2, 3)", pz.CoordinateXYZ)
+	}
+}
+
+func TestTWKBGeometryCollectionIndependentCursors(t *testing.T) {
+	// Regression check for the per-member cursor reset: without it, the
+	// second member's delta chain would be polluted by the first
+	// member's final (x, y), corrupting its decoded coordinates.
+	wkt := "GEOMETRYCOLLECTION (POINT (100 100), POINT (1 1))"
+	assertWKTRoundTrip(t, wkt, DefaultOptions())
+}
@@ -0,0 +1,269 @@
+package geometry
+
+import "math"
+
+// This file implements STTranslate, STScale, STRotate, STAffine and
+// STSnapToGrid for every concrete geometry type, all built on top of the
+// single transformCoordinates helper: each per-type method just supplies
+// the coordinate-mapping function and lets transformCoordinates walk the
+// geometry's structure, so Point/LineString/Polygon/Multi*/
+// GeometryCollection never need their own bespoke transform logic.
+//
+// Unlike most of this package's methods, these do not return an error:
+// they follow STBuffer's precedent of a best-effort, error-free signature
+// (per the Geometry interface), so a type that cannot be walked (e.g. one
+// of the Z variants, not yet wired into transformCoordinates) returns nil
+// rather than (nil, ErrUnsupported).
+
+// transformCoordinates returns a copy of g with every coordinate replaced
+// by f(coordinate), preserving structure (rings, SRID, emptiness) and
+// recursing into Multi*/GeometryCollection members. It returns nil for
+// geometry types it does not know how to walk.
+func transformCoordinates(g Geometry, f func(Coordinate) Coordinate) Geometry {
+	switch v := g.(type) {
+	case *Point:
+		if v.Empty {
+			p := NewEmptyPoint()
+			p.SRID = v.SRID
+			return p
+		}
+		nc := f(v.Coordinate)
+		p := NewPoint(nc.X, nc.Y)
+		p.SRID = v.SRID
+		return p
+	case *LineString:
+		l := NewLineString(transformCoordinateSlice(v.Points, f))
+		l.SRID = v.SRID
+		return l
+	case *Polygon:
+		holes := make([][]Coordinate, len(v.Holes))
+		for i, h := range v.Holes {
+			holes[i] = transformCoordinateSlice(h, f)
+		}
+		pg := NewPolygon(transformCoordinateSlice(v.Shell, f), holes)
+		pg.SRID = v.SRID
+		return pg
+	case *MultiPoint:
+		points := make([]*Point, len(v.Points))
+		for i, p := range v.Points {
+			points[i] = transformCoordinates(p, f).(*Point)
+		}
+		mp := NewMultiPoint(points)
+		mp.SRID = v.SRID
+		return mp
+	case *MultiLineString:
+		lines := make([]*LineString, len(v.Lines))
+		for i, l := range v.Lines {
+			lines[i] = transformCoordinates(l, f).(*LineString)
+		}
+		ml := NewMultiLineString(lines)
+		ml.SRID = v.SRID
+		return ml
+	case *MultiPolygon:
+		polys := make([]*Polygon, len(v.Polygons))
+		for i, pg := range v.Polygons {
+			polys[i] = transformCoordinates(pg, f).(*Polygon)
+		}
+		mp := NewMultiPolygon(polys)
+		mp.SRID = v.SRID
+		return mp
+	case *GeometryCollection:
+		geoms := make([]Geometry, len(v.Geometries))
+		for i, sub := range v.Geometries {
+			geoms[i] = transformCoordinates(sub, f)
+		}
+		gc := NewGeometryCollection(geoms)
+		gc.SRID = v.SRID
+		return gc
+	default:
+		return nil
+	}
+}
+
+func transformCoordinateSlice(coords []Coordinate, f func(Coordinate) Coordinate) []Coordinate {
+	out := make([]Coordinate, len(coords))
+	for i, c := range coords {
+		out[i] = f(c)
+	}
+	return out
+}
+
+// snapToGrid quantizes a coordinate to the nearest multiple of size.
+func snapToGrid(c Coordinate, size float64) Coordinate {
+	if size <= 0 {
+		return c
+	}
+	return Coordinate{
+		X: math.Round(c.X/size) * size,
+		Y: math.Round(c.Y/size) * size,
+	}
+}
+
+// collapseDegenerate removes consecutive duplicate coordinates left
+// behind by snapping nearby vertices onto the same grid cell, the way
+// STSnapToGrid's degenerate-segment collapsing is specified to behave.
+func collapseDegenerate(coords []Coordinate) []Coordinate {
+	if len(coords) == 0 {
+		return coords
+	}
+	out := coords[:1]
+	for _, c := range coords[1:] {
+		if !coordEqual(c, out[len(out)-1]) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func snapGeometryToGrid(g Geometry, size float64) Geometry {
+	if gc, ok := g.(*GeometryCollection); ok {
+		geoms := make([]Geometry, len(gc.Geometries))
+		for i, sub := range gc.Geometries {
+			geoms[i] = snapGeometryToGrid(sub, size)
+		}
+		out := NewGeometryCollection(geoms)
+		out.SRID = gc.SRID
+		return out
+	}
+	snapped := transformCoordinates(g, func(c Coordinate) Coordinate { return snapToGrid(c, size) })
+	switch v := snapped.(type) {
+	case *LineString:
+		v.Points = collapseDegenerate(v.Points)
+	case *Polygon:
+		v.Shell = collapseDegenerate(v.Shell)
+		for i, h := range v.Holes {
+			v.Holes[i] = collapseDegenerate(h)
+		}
+	case *MultiLineString:
+		for _, l := range v.Lines {
+			l.Points = collapseDegenerate(l.Points)
+		}
+	case *MultiPolygon:
+		for _, pg := range v.Polygons {
+			pg.Shell = collapseDegenerate(pg.Shell)
+			for i, h := range pg.Holes {
+				pg.Holes[i] = collapseDegenerate(h)
+			}
+		}
+	}
+	return snapped
+}
+
+func translateFunc(dx, dy float64) func(Coordinate) Coordinate {
+	return func(c Coordinate) Coordinate { return Coordinate{X: c.X + dx, Y: c.Y + dy} }
+}
+
+func scaleFunc(sx, sy float64) func(Coordinate) Coordinate {
+	return func(c Coordinate) Coordinate { return Coordinate{X: c.X * sx, Y: c.Y * sy} }
+}
+
+func rotateFunc(radians float64, origin Coordinate) func(Coordinate) Coordinate {
+	sin, cos := math.Sin(radians), math.Cos(radians)
+	return func(c Coordinate) Coordinate {
+		x, y := c.X-origin.X, c.Y-origin.Y
+		return Coordinate{
+			X: origin.X + x*cos-y*sin,
+			Y: origin.Y + x*sin+y*cos,
+		}
+	}
+}
+
+func affineFunc(a, b, d, e, xoff, yoff float64) func(Coordinate) Coordinate {
+	return func(c Coordinate) Coordinate {
+		return Coordinate{X: a*c.X + b*c.Y + xoff, Y: d*c.X + e*c.Y + yoff}
+	}
+}
+
+func stTranslate(self Geometry, dx, dy float64) Geometry {
+	return transformCoordinates(self, translateFunc(dx, dy))
+}
+
+func stScale(self Geometry, sx, sy float64) Geometry {
+	return transformCoordinates(self, scaleFunc(sx, sy))
+}
+
+func stRotate(self Geometry, radians float64, origin *Point) Geometry {
+	o := Coordinate{}
+	if origin != nil {
+		o = origin.Coordinate
+	}
+	return transformCoordinates(self, rotateFunc(radians, o))
+}
+
+func stAffine(self Geometry, a, b, d, e, xoff, yoff float64) Geometry {
+	return transformCoordinates(self, affineFunc(a, b, d, e, xoff, yoff))
+}
+
+func stSnapToGrid(self Geometry, size float64) Geometry {
+	return snapGeometryToGrid(self, size)
+}
+
+func (p *Point) STTranslate(dx, dy float64) Geometry { return stTranslate(p, dx, dy) }
+func (p *Point) STScale(sx, sy float64) Geometry      { return stScale(p, sx, sy) }
+func (p *Point) STRotate(radians float64, origin *Point) Geometry {
+	return stRotate(p, radians, origin)
+}
+func (p *Point) STAffine(a, b, d, e, xoff, yoff float64) Geometry {
+	return stAffine(p, a, b, d, e, xoff, yoff)
+}
+func (p *Point) STSnapToGrid(size float64) Geometry { return stSnapToGrid(p, size) }
+
+func (l *LineString) STTranslate(dx, dy float64) Geometry { return stTranslate(l, dx, dy) }
+func (l *LineString) STScale(sx, sy float64) Geometry      { return stScale(l, sx, sy) }
+func (l *LineString) STRotate(radians float64, origin *Point) Geometry {
+	return stRotate(l, radians, origin)
+}
+func (l *LineString) STAffine(a, b, d, e, xoff, yoff float64) Geometry {
+	return stAffine(l, a, b, d, e, xoff, yoff)
+}
+func (l *LineString) STSnapToGrid(size float64) Geometry { return stSnapToGrid(l, size) }
+
+func (pg *Polygon) STTranslate(dx, dy float64) Geometry { return stTranslate(pg, dx, dy) }
+func (pg *Polygon) STScale(sx, sy float64) Geometry      { return stScale(pg, sx, sy) }
+func (pg *Polygon) STRotate(radians float64, origin *Point) Geometry {
+	return stRotate(pg, radians, origin)
+}
+func (pg *Polygon) STAffine(a, b, d, e, xoff, yoff float64) Geometry {
+	return stAffine(pg, a, b, d, e, xoff, yoff)
+}
+func (pg *Polygon) STSnapToGrid(size float64) Geometry { return stSnapToGrid(pg, size) }
+
+func (mp *MultiPoint) STTranslate(dx, dy float64) Geometry { return stTranslate(mp, dx, dy) }
+func (mp *MultiPoint) STScale(sx, sy float64) Geometry      { return stScale(mp, sx, sy) }
+func (mp *MultiPoint) STRotate(radians float64, origin *Point) Geometry {
+	return stRotate(mp, radians, origin)
+}
+func (mp *MultiPoint) STAffine(a, b, d, e, xoff, yoff float64) Geometry {
+	return stAffine(mp, a, b, d, e, xoff, yoff)
+}
+func (mp *MultiPoint) STSnapToGrid(size float64) Geometry { return stSnapToGrid(mp, size) }
+
+func (ml *MultiLineString) STTranslate(dx, dy float64) Geometry { return stTranslate(ml, dx, dy) }
+func (ml *MultiLineString) STScale(sx, sy float64) Geometry      { return stScale(ml, sx, sy) }
+func (ml *MultiLineString) STRotate(radians float64, origin *Point) Geometry {
+	return stRotate(ml, radians, origin)
+}
+func (ml *MultiLineString) STAffine(a, b, d, e, xoff, yoff float64) Geometry {
+	return stAffine(ml, a, b, d, e, xoff, yoff)
+}
+func (ml *MultiLineString) STSnapToGrid(size float64) Geometry { return stSnapToGrid(ml, size) }
+
+func (mp *MultiPolygon) STTranslate(dx, dy float64) Geometry { return stTranslate(mp, dx, dy) }
+func (mp *MultiPolygon) STScale(sx, sy float64) Geometry      { return stScale(mp, sx, sy) }
+func (mp *MultiPolygon) STRotate(radians float64, origin *Point) Geometry {
+	return stRotate(mp, radians, origin)
+}
+func (mp *MultiPolygon) STAffine(a, b, d, e, xoff, yoff float64) Geometry {
+	return stAffine(mp, a, b, d, e, xoff, yoff)
+}
+func (mp *MultiPolygon) STSnapToGrid(size float64) Geometry { return stSnapToGrid(mp, size) }
+
+func (gc *GeometryCollection) STTranslate(dx, dy float64) Geometry { return stTranslate(gc, dx, dy) }
+func (gc *GeometryCollection) STScale(sx, sy float64) Geometry      { return stScale(gc, sx, sy) }
+func (gc *GeometryCollection) STRotate(radians float64, origin *Point) Geometry {
+	return stRotate(gc, radians, origin)
+}
+func (gc *GeometryCollection) STAffine(a, b, d, e, xoff, yoff float64) Geometry {
+	return stAffine(gc, a, b, d, e, xoff, yoff)
+}
+func (gc *GeometryCollection) STSnapToGrid(size float64) Geometry { return stSnapToGrid(gc, size) }
@@ -0,0 +1,82 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSTTranslate(t *testing.T) {
+	p := NewPoint(1, 2)
+	got := p.STTranslate(10, -5).(*Point)
+	if got.X != 11 || got.Y != -3 {
+		t.Errorf("STTranslate = (%v, %v), want (11, -3)", got.X, got.Y)
+	}
+	if p.X != 1 || p.Y != 2 {
+		t.Errorf("STTranslate mutated the receiver: %v", p.Coordinate)
+	}
+}
+
+func TestSTScale(t *testing.T) {
+	p := NewPoint(2, 3)
+	got := p.STScale(2, 4).(*Point)
+	if got.X != 4 || got.Y != 12 {
+		t.Errorf("STScale = (%v, %v), want (4, 12)", got.X, got.Y)
+	}
+}
+
+func TestSTRotateAboutOrigin(t *testing.T) {
+	p := NewPoint(1, 0)
+	got := p.STRotate(math.Pi/2, nil).(*Point)
+	if math.Abs(got.X) > 1e-9 || math.Abs(got.Y-1) > 1e-9 {
+		t.Errorf("STRotate 90deg about origin = (%v, %v), want ~(0, 1)", got.X, got.Y)
+	}
+}
+
+func TestSTRotateAboutPoint(t *testing.T) {
+	p := NewPoint(2, 1)
+	origin := NewPoint(1, 1)
+	got := p.STRotate(math.Pi/2, origin).(*Point)
+	if math.Abs(got.X-1) > 1e-9 || math.Abs(got.Y-2) > 1e-9 {
+		t.Errorf("STRotate 90deg about (1,1) = (%v, %v), want ~(1, 2)", got.X, got.Y)
+	}
+}
+
+func TestSTAffine(t *testing.T) {
+	p := NewPoint(1, 1)
+	// Identity matrix plus a translation.
+	got := p.STAffine(1, 0, 0, 1, 5, 5).(*Point)
+	if got.X != 6 || got.Y != 6 {
+		t.Errorf("STAffine = (%v, %v), want (6, 6)", got.X, got.Y)
+	}
+}
+
+func TestSTSnapToGrid(t *testing.T) {
+	l := NewLineString([]Coordinate{{X: 0.1, Y: 0.1}, {X: 0.2, Y: 0.2}, {X: 5, Y: 5}})
+	got := l.STSnapToGrid(1).(*LineString)
+	if len(got.Points) != 2 {
+		t.Fatalf("got %d points, want 2 (the first two snap to the same grid point and collapse)", len(got.Points))
+	}
+	if got.Points[0].X != 0 || got.Points[0].Y != 0 {
+		t.Errorf("first point = %v, want (0, 0)", got.Points[0])
+	}
+	if got.Points[1].X != 5 || got.Points[1].Y != 5 {
+		t.Errorf("second point = %v, want (5, 5)", got.Points[1])
+	}
+}
+
+func TestAffineOnPolygonPreservesHoles(t *testing.T) {
+	pg := NewPolygon(
+		[]Coordinate{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0}},
+		[][]Coordinate{{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 2}, {X: 1, Y: 1}}},
+	)
+	got := pg.STTranslate(10, 10).(*Polygon)
+	if len(got.Holes) != 1 {
+		t.Fatalf("got %d holes, want 1", len(got.Holes))
+	}
+	if got.Shell[0].X != 10 || got.Shell[0].Y != 10 {
+		t.Errorf("shell[0] = %v, want (10, 10)", got.Shell[0])
+	}
+	if got.Holes[0][0].X != 11 || got.Holes[0][0].Y != 11 {
+		t.Errorf("hole[0][0] = %v, want (11, 11)", got.Holes[0][0])
+	}
+}
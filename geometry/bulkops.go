@@ -0,0 +1,107 @@
+package geometry
+
+import "github.com/MathewBravo/gospatial/index"
+
+// This file implements bulk predicate queries over collections of
+// geometries, backed by an index.STRtree so that testing every a against
+// every b costs O((N+M) log N) rather than O(N*M) naive pairwise
+// comparisons.
+//
+// index.STRtree.Build takes a slice of index.Bounded rather than
+// []Geometry directly (as the rest of the package's bulk/index naming
+// might suggest) because the index package must not import geometry, to
+// avoid the reverse import this file itself needs; boundGeometry below is
+// the adapter that bridges the two, the same way FromWKT/FromWKB live
+// inside this package rather than a separate io subpackage to dodge an
+// analogous cycle.
+
+// boundGeometry pairs a Geometry with its precomputed bounds so it can be
+// staged into an index.STRtree.
+type boundGeometry struct {
+	index int
+	geom  Geometry
+	env   Envelope
+}
+
+func (b boundGeometry) Bounds() index.Envelope {
+	return index.Envelope{MinX: b.env.MinX, MinY: b.env.MinY, MaxX: b.env.MaxX, MaxY: b.env.MaxY}
+}
+
+func buildBoundIndex(geoms []Geometry) (*index.STRtree, error) {
+	items := make([]index.Bounded, 0, len(geoms))
+	for i, g := range geoms {
+		env, err := Bounds(g)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, boundGeometry{index: i, geom: g, env: env})
+	}
+	tree := index.NewSTRtree()
+	tree.Build(items)
+	return tree, nil
+}
+
+// STIntersectionMap returns, for every geometry in a, the indices of the
+// geometries in b it intersects (per STIntersects), without testing every
+// a-b pair directly: an STRtree built from b's envelopes first narrows
+// each a down to its bounding-box candidates.
+func STIntersectionMap(a, b []Geometry) (map[int][]int, error) {
+	tree, err := buildBoundIndex(b)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int][]int)
+	for i, ga := range a {
+		envA, err := Bounds(ga)
+		if err != nil {
+			return nil, err
+		}
+		var matches []int
+		for _, item := range tree.Query(index.Envelope{MinX: envA.MinX, MinY: envA.MinY, MaxX: envA.MaxX, MaxY: envA.MaxY}) {
+			cand := item.(boundGeometry)
+			ok, err := ga.STIntersects(&cand.geom)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matches = append(matches, cand.index)
+			}
+		}
+		if len(matches) > 0 {
+			result[i] = matches
+		}
+	}
+	return result, nil
+}
+
+// STContainsPairs returns, for every geometry in a, the indices of the
+// geometries in b that it contains (per STContains), narrowed the same
+// way as STIntersectionMap.
+func STContainsPairs(a, b []Geometry) (map[int][]int, error) {
+	tree, err := buildBoundIndex(b)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int][]int)
+	for i, ga := range a {
+		envA, err := Bounds(ga)
+		if err != nil {
+			return nil, err
+		}
+		var matches []int
+		for _, item := range tree.Query(index.Envelope{MinX: envA.MinX, MinY: envA.MinY, MaxX: envA.MaxX, MaxY: envA.MaxY}) {
+			cand := item.(boundGeometry)
+			ok, err := ga.STContains(&cand.geom)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matches = append(matches, cand.index)
+			}
+		}
+		if len(matches) > 0 {
+			result[i] = matches
+		}
+	}
+	return result, nil
+}
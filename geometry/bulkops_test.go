@@ -0,0 +1,43 @@
+package geometry
+
+import "testing"
+
+func TestSTIntersectionMap(t *testing.T) {
+	a := []Geometry{
+		mustWKT(t, "POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))"),
+		mustWKT(t, "POLYGON ((10 10, 12 10, 12 12, 10 12, 10 10))"),
+	}
+	b := []Geometry{
+		mustWKT(t, "POINT (1 1)"),
+		mustWKT(t, "POINT (100 100)"),
+	}
+
+	got, err := STIntersectionMap(a, b)
+	if err != nil {
+		t.Fatalf("STIntersectionMap: %v", err)
+	}
+	if len(got[0]) != 1 || got[0][0] != 0 {
+		t.Errorf("got[0] = %v, want [0] (a[0] contains b[0])", got[0])
+	}
+	if len(got[1]) != 0 {
+		t.Errorf("got[1] = %v, want empty (a[1] doesn't intersect any b)", got[1])
+	}
+}
+
+func TestSTContainsPairs(t *testing.T) {
+	a := []Geometry{
+		mustWKT(t, "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0))"),
+	}
+	b := []Geometry{
+		mustWKT(t, "POINT (2 2)"),
+		mustWKT(t, "POINT (10 10)"),
+	}
+
+	got, err := STContainsPairs(a, b)
+	if err != nil {
+		t.Fatalf("STContainsPairs: %v", err)
+	}
+	if len(got[0]) != 1 || got[0][0] != 0 {
+		t.Errorf("got[0] = %v, want [0] (a[0] contains b[0] only)", got[0])
+	}
+}
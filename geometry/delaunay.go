@@ -0,0 +1,259 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// This file implements STDelaunayTriangles via an incremental Bowyer-Watson
+// triangulation. STVoronoiPolygons (voronoi.go) reuses the same
+// triangulation as its dual.
+
+type triangle struct {
+	a, b, c Coordinate
+}
+
+// makeTriangle returns a triangle with its vertices in counter-clockwise
+// order, which inCircumcircle depends on.
+func makeTriangle(a, b, c Coordinate) triangle {
+	if orientation(a, b, c) < 0 {
+		b, c = c, b
+	}
+	return triangle{a: a, b: b, c: c}
+}
+
+func (t triangle) hasVertex(p Coordinate) bool {
+	return coordEqual(t.a, p) || coordEqual(t.b, p) || coordEqual(t.c, p)
+}
+
+// inCircumcircle reports whether p lies inside the circumcircle of the
+// (counter-clockwise) triangle a-b-c, via the standard determinant test.
+func inCircumcircle(a, b, c, p Coordinate) bool {
+	ax, ay := a.X-p.X, a.Y-p.Y
+	bx, by := b.X-p.X, b.Y-p.Y
+	cx, cy := c.X-p.X, c.Y-p.Y
+	det := (ax*ax+ay*ay)*(bx*cy-cx*by) -
+		(bx*bx+by*by)*(ax*cy-cx*ay) +
+		(cx*cx+cy*cy)*(ax*by-bx*ay)
+	return det > epsilon
+}
+
+func superTriangle(env Envelope) triangle {
+	dx, dy := env.MaxX-env.MinX, env.MaxY-env.MinY
+	d := math.Max(dx, dy)
+	if d == 0 {
+		d = 1
+	}
+	midX, midY := (env.MinX+env.MaxX)/2, (env.MinY+env.MaxY)/2
+	return makeTriangle(
+		Coordinate{X: midX - 20*d, Y: midY - d},
+		Coordinate{X: midX, Y: midY + 20*d},
+		Coordinate{X: midX + 20*d, Y: midY - d},
+	)
+}
+
+// cavityBoundary returns the edges of the "bad" triangles (those whose
+// circumcircle contains the newly inserted point) that are not shared with
+// another bad triangle, i.e. the boundary of the polygonal cavity they
+// leave behind.
+func cavityBoundary(bad []triangle) [][2]Coordinate {
+	edgesOf := func(t triangle) [3][2]Coordinate {
+		return [3][2]Coordinate{{t.a, t.b}, {t.b, t.c}, {t.c, t.a}}
+	}
+	seen := make(map[[2]Coordinate]int, len(bad)*3)
+	for _, t := range bad {
+		for _, e := range edgesOf(t) {
+			seen[e]++
+		}
+	}
+	var boundary [][2]Coordinate
+	for _, t := range bad {
+		for _, e := range edgesOf(t) {
+			rev := [2]Coordinate{e[1], e[0]}
+			if seen[rev] == 0 {
+				boundary = append(boundary, e)
+			}
+		}
+	}
+	return boundary
+}
+
+// delaunayTriangulate computes the Delaunay triangulation of points via
+// incremental Bowyer-Watson insertion on a super-triangle, which is
+// stripped out of the result before returning.
+func delaunayTriangulate(points []Coordinate) []triangle {
+	if len(points) < 3 {
+		return nil
+	}
+	super := superTriangle(envelopeOf(points))
+	triangles := []triangle{super}
+
+	for _, p := range points {
+		var bad, rest []triangle
+		for _, t := range triangles {
+			if inCircumcircle(t.a, t.b, t.c, p) {
+				bad = append(bad, t)
+			} else {
+				rest = append(rest, t)
+			}
+		}
+		for _, e := range cavityBoundary(bad) {
+			rest = append(rest, makeTriangle(e[0], e[1], p))
+		}
+		triangles = rest
+	}
+
+	final := triangles[:0]
+	for _, t := range triangles {
+		if t.hasVertex(super.a) || t.hasVertex(super.b) || t.hasVertex(super.c) {
+			continue
+		}
+		final = append(final, t)
+	}
+	return final
+}
+
+func dedupeCoordinates(pts []Coordinate) []Coordinate {
+	seen := make(map[Coordinate]struct{}, len(pts))
+	out := make([]Coordinate, 0, len(pts))
+	for _, p := range pts {
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		out = append(out, p)
+	}
+	return out
+}
+
+// snapPoints quantizes every coordinate to the nearest multiple of
+// tolerance (when positive) before deduplicating, merging cocircular or
+// near-duplicate input the way ST_DelaunayTriangles/ST_VoronoiPolygons do.
+func snapPoints(pts []Coordinate, tolerance float64) []Coordinate {
+	if tolerance <= 0 {
+		return dedupeCoordinates(pts)
+	}
+	snapped := make([]Coordinate, len(pts))
+	for i, p := range pts {
+		snapped[i] = Coordinate{
+			X: math.Round(p.X/tolerance) * tolerance,
+			Y: math.Round(p.Y/tolerance) * tolerance,
+		}
+	}
+	return dedupeCoordinates(snapped)
+}
+
+// extractPoints flattens every coordinate referenced by a geometry (its
+// own position, vertices, or the recursive union of a collection's
+// members) into a single point set to triangulate.
+func extractPoints(g Geometry) ([]Coordinate, error) {
+	switch v := g.(type) {
+	case *Point:
+		if v.Empty {
+			return nil, nil
+		}
+		return []Coordinate{v.Coordinate}, nil
+	case *MultiPoint:
+		var pts []Coordinate
+		for _, p := range v.Points {
+			if !p.Empty {
+				pts = append(pts, p.Coordinate)
+			}
+		}
+		return pts, nil
+	case *LineString:
+		return append([]Coordinate{}, v.Points...), nil
+	case *MultiLineString:
+		var pts []Coordinate
+		for _, l := range v.Lines {
+			pts = append(pts, l.Points...)
+		}
+		return pts, nil
+	case *Polygon:
+		pts := append([]Coordinate{}, v.Shell...)
+		for _, h := range v.Holes {
+			pts = append(pts, h...)
+		}
+		return pts, nil
+	case *MultiPolygon:
+		var pts []Coordinate
+		for _, p := range v.Polygons {
+			sub, err := extractPoints(p)
+			if err != nil {
+				return nil, err
+			}
+			pts = append(pts, sub...)
+		}
+		return pts, nil
+	case *GeometryCollection:
+		var pts []Coordinate
+		for _, sub := range v.Geometries {
+			p, err := extractPoints(sub)
+			if err != nil {
+				return nil, err
+			}
+			pts = append(pts, p...)
+		}
+		return pts, nil
+	default:
+		return nil, ErrUnsupported
+	}
+}
+
+func canonicalEdge(a, b Coordinate) [2]Coordinate {
+	if a.X < b.X || (a.X == b.X && a.Y < b.Y) {
+		return [2]Coordinate{a, b}
+	}
+	return [2]Coordinate{b, a}
+}
+
+func closeRing(ring []Coordinate) []Coordinate {
+	if len(ring) == 0 || coordEqual(ring[0], ring[len(ring)-1]) {
+		return ring
+	}
+	return append(ring, ring[0])
+}
+
+// STDelaunayTriangles computes the Delaunay triangulation of the vertices
+// of points, returned as a MultiPolygon of triangles (flags == 0) or a
+// MultiLineString of the triangulation's edges (flags == 1).
+func STDelaunayTriangles(points Geometry, tolerance float64, flags int) (Geometry, error) {
+	raw, err := extractPoints(points)
+	if err != nil {
+		return nil, err
+	}
+	pts := snapPoints(raw, tolerance)
+	if len(pts) < 3 {
+		return nil, fmt.Errorf("geometry: %w: STDelaunayTriangles needs at least 3 distinct points", ErrMalformed)
+	}
+
+	tris := delaunayTriangulate(pts)
+
+	switch flags {
+	case 0:
+		polys := make([]*Polygon, len(tris))
+		for i, t := range tris {
+			polys[i] = NewPolygon(closeRing([]Coordinate{t.a, t.b, t.c}), nil)
+		}
+		return NewMultiPolygon(polys), nil
+	case 1:
+		seen := make(map[[2]Coordinate]bool)
+		var lines []*LineString
+		addEdge := func(a, b Coordinate) {
+			key := canonicalEdge(a, b)
+			if seen[key] {
+				return
+			}
+			seen[key] = true
+			lines = append(lines, NewLineString([]Coordinate{a, b}))
+		}
+		for _, t := range tris {
+			addEdge(t.a, t.b)
+			addEdge(t.b, t.c)
+			addEdge(t.c, t.a)
+		}
+		return NewMultiLineString(lines), nil
+	default:
+		return nil, fmt.Errorf("geometry: %w: unknown STDelaunayTriangles flags value %d", ErrMalformed, flags)
+	}
+}
@@ -0,0 +1,52 @@
+package geometry
+
+import "testing"
+
+func TestSTDelaunayTrianglesSquare(t *testing.T) {
+	pts := NewMultiPoint([]*Point{
+		NewPoint(0, 0), NewPoint(4, 0), NewPoint(4, 4), NewPoint(0, 4),
+	})
+
+	g, err := STDelaunayTriangles(pts, 1e-9, 0)
+	if err != nil {
+		t.Fatalf("STDelaunayTriangles: %v", err)
+	}
+	mp, ok := g.(*MultiPolygon)
+	if !ok {
+		t.Fatalf("expected *MultiPolygon, got %T", g)
+	}
+	// Four co-planar points split into exactly two triangles.
+	if len(mp.Polygons) != 2 {
+		t.Errorf("got %d triangles, want 2", len(mp.Polygons))
+	}
+	for _, tri := range mp.Polygons {
+		if len(tri.Shell) != 4 { // 3 vertices + closing point
+			t.Errorf("triangle shell has %d points, want 4 (closed ring)", len(tri.Shell))
+		}
+	}
+}
+
+func TestSTDelaunayTrianglesRequiresThreePoints(t *testing.T) {
+	pts := NewMultiPoint([]*Point{NewPoint(0, 0), NewPoint(1, 1)})
+	if _, err := STDelaunayTriangles(pts, 1e-9, 0); err == nil {
+		t.Errorf("expected error triangulating only 2 distinct points")
+	}
+}
+
+func TestSTDelaunayTrianglesEdgesMode(t *testing.T) {
+	pts := NewMultiPoint([]*Point{
+		NewPoint(0, 0), NewPoint(4, 0), NewPoint(4, 4), NewPoint(0, 4),
+	})
+
+	g, err := STDelaunayTriangles(pts, 1e-9, 1)
+	if err != nil {
+		t.Fatalf("STDelaunayTriangles: %v", err)
+	}
+	ml, ok := g.(*MultiLineString)
+	if !ok {
+		t.Fatalf("expected *MultiLineString for edges mode, got %T", g)
+	}
+	if len(ml.Lines) == 0 {
+		t.Errorf("expected at least one edge")
+	}
+}
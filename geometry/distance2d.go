@@ -0,0 +1,195 @@
+package geometry
+
+import "math"
+
+// This file implements STDistance and STDWithin for Point, LineString and
+// Polygon.
+//
+// As with the 3D operations in threed.go, polygons are treated via their
+// boundary rings only: distance against a polygon falls back to distance
+// against its edges, not its filled interior. To keep that from breaking
+// the basic "is this point part of the polygon" case, stDistance first
+// checks whether any point/vertex of the other operand lies inside (or
+// on the boundary of) a Polygon operand via pointInPolygon and
+// short-circuits to 0 if so; only genuinely disjoint-from-the-interior
+// cases fall through to the boundary-only distance below. A LineString
+// or Polygon that passes entirely through another polygon's interior
+// without ever placing a vertex inside it is still measured against the
+// boundary only -- exact interior-aware distance is left for when this
+// package grows real polygon clipping.
+
+type primitive2D struct {
+	points   []Coordinate
+	segments [][2]Coordinate
+}
+
+func (p primitive2D) empty() bool { return len(p.points) == 0 && len(p.segments) == 0 }
+
+func primitiveOf2D(g Geometry) (prim primitive2D, ok bool) {
+	switch v := g.(type) {
+	case *Point:
+		if v.Empty {
+			return primitive2D{}, true
+		}
+		return primitive2D{points: []Coordinate{v.Coordinate}}, true
+	case *LineString:
+		return primitive2D{segments: ringSegments2D(v.Points, false)}, true
+	case *Polygon:
+		segs := ringSegments2D(v.Shell, true)
+		for _, h := range v.Holes {
+			segs = append(segs, ringSegments2D(h, true)...)
+		}
+		return primitive2D{segments: segs}, true
+	default:
+		return primitive2D{}, false
+	}
+}
+
+// ringSegments2D breaks pts into consecutive segments, closing back to
+// pts[0] when asRing is true (for polygon boundaries) rather than only
+// when pts is already explicitly closed (for open LineStrings).
+func ringSegments2D(pts []Coordinate, asRing bool) [][2]Coordinate {
+	n := len(pts)
+	if n < 2 {
+		return nil
+	}
+	if !asRing {
+		segs := make([][2]Coordinate, n-1)
+		for i := 0; i < n-1; i++ {
+			segs[i] = [2]Coordinate{pts[i], pts[i+1]}
+		}
+		return segs
+	}
+	closed := coordEqual(pts[0], pts[n-1])
+	count := n - 1
+	if !closed {
+		count = n
+	}
+	segs := make([][2]Coordinate, count)
+	for i := 0; i < count; i++ {
+		segs[i] = [2]Coordinate{pts[i], pts[(i+1)%n]}
+	}
+	return segs
+}
+
+func distance2D(a, b Coordinate) float64 {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+func pointSegmentDistance2D(p, a, b Coordinate) float64 {
+	t := clamp01(paramOf(a, b, p))
+	return distance2D(p, lerp(a, b, t))
+}
+
+// segmentSegmentDistance2D returns the minimum distance between segments
+// a1-a2 and b1-b2, including 0 when they cross or touch.
+func segmentSegmentDistance2D(a1, a2, b1, b2 Coordinate) float64 {
+	if segmentIntersect(a1, a2, b1, b2) != segNone {
+		return 0
+	}
+	best := pointSegmentDistance2D(a1, b1, b2)
+	if d := pointSegmentDistance2D(a2, b1, b2); d < best {
+		best = d
+	}
+	if d := pointSegmentDistance2D(b1, a1, a2); d < best {
+		best = d
+	}
+	if d := pointSegmentDistance2D(b2, a1, a2); d < best {
+		best = d
+	}
+	return best
+}
+
+// primitivePoints2D returns every discrete coordinate a primitive is
+// built from, whether it's a point sample or a segment endpoint --
+// enough to test against pointInPolygon without needing the original
+// geometry's own coordinate slices.
+func primitivePoints2D(p primitive2D) []Coordinate {
+	pts := append([]Coordinate{}, p.points...)
+	for _, s := range p.segments {
+		pts = append(pts, s[0], s[1])
+	}
+	return pts
+}
+
+// intersectsPolygonInterior reports whether any point of prim lies
+// inside or on the boundary of pg, per pointInPolygon.
+func intersectsPolygonInterior(prim primitive2D, pg *Polygon) bool {
+	for _, c := range primitivePoints2D(prim) {
+		switch pointInPolygon(c, pg.Shell, pg.Holes) {
+		case 'I', 'B':
+			return true
+		}
+	}
+	return false
+}
+
+func primitiveDistance2D(a, b primitive2D) float64 {
+	best := math.Inf(1)
+	consider := func(d float64) {
+		if d < best {
+			best = d
+		}
+	}
+	for _, pa := range a.points {
+		for _, pb := range b.points {
+			consider(distance2D(pa, pb))
+		}
+		for _, sb := range b.segments {
+			consider(pointSegmentDistance2D(pa, sb[0], sb[1]))
+		}
+	}
+	for _, sa := range a.segments {
+		for _, pb := range b.points {
+			consider(pointSegmentDistance2D(pb, sa[0], sa[1]))
+		}
+		for _, sb := range b.segments {
+			consider(segmentSegmentDistance2D(sa[0], sa[1], sb[0], sb[1]))
+		}
+	}
+	return best
+}
+
+func stDistance(self Geometry, g *Geometry) (float64, error) {
+	other := derefGeom(g)
+	pa, okA := primitiveOf2D(self)
+	pb, okB := primitiveOf2D(other)
+	if !okA || !okB {
+		return 0, ErrUnsupported
+	}
+	if pa.empty() || pb.empty() {
+		return 0, errEmptyGeometry
+	}
+	if pg, ok := self.(*Polygon); ok && intersectsPolygonInterior(pb, pg) {
+		return 0, nil
+	}
+	if pg, ok := other.(*Polygon); ok && intersectsPolygonInterior(pa, pg) {
+		return 0, nil
+	}
+	return primitiveDistance2D(pa, pb), nil
+}
+
+// stDWithin reports whether self and g lie within distance of each other,
+// derived from the same planar distance stDistance computes.
+func stDWithin(self Geometry, g *Geometry, distance float64) (bool, error) {
+	d, err := stDistance(self, g)
+	if err != nil {
+		return false, err
+	}
+	return d <= distance, nil
+}
+
+func (p *Point) STDistance(g *Geometry) (float64, error)      { return stDistance(p, g) }
+func (l *LineString) STDistance(g *Geometry) (float64, error) { return stDistance(l, g) }
+func (pg *Polygon) STDistance(g *Geometry) (float64, error)   { return stDistance(pg, g) }
+
+func (p *Point) STDWithin(g *Geometry, distance float64) (bool, error) {
+	return stDWithin(p, g, distance)
+}
+func (l *LineString) STDWithin(g *Geometry, distance float64) (bool, error) {
+	return stDWithin(l, g, distance)
+}
+func (pg *Polygon) STDWithin(g *Geometry, distance float64) (bool, error) {
+	return stDWithin(pg, g, distance)
+}
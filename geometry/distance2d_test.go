@@ -0,0 +1,85 @@
+package geometry
+
+import "testing"
+
+func TestSTDistancePoints(t *testing.T) {
+	a := NewPoint(0, 0)
+	var b Geometry = NewPoint(3, 4)
+
+	dist, err := a.STDistance(&b)
+	if err != nil {
+		t.Fatalf("STDistance: %v", err)
+	}
+	if dist != 5 {
+		t.Errorf("STDistance = %v, want 5", dist)
+	}
+}
+
+func TestSTDistancePointToLineString(t *testing.T) {
+	p := NewPoint(0, 5)
+	var l Geometry = NewLineString([]Coordinate{{X: 0, Y: 0}, {X: 10, Y: 0}})
+
+	dist, err := p.STDistance(&l)
+	if err != nil {
+		t.Fatalf("STDistance: %v", err)
+	}
+	if dist != 5 {
+		t.Errorf("STDistance = %v, want 5 (perpendicular distance to the segment)", dist)
+	}
+}
+
+func TestSTDWithin(t *testing.T) {
+	a := NewPoint(0, 0)
+	var near Geometry = NewPoint(1, 0)
+	var far Geometry = NewPoint(100, 0)
+
+	within, err := a.STDWithin(&near, 5)
+	if err != nil {
+		t.Fatalf("STDWithin: %v", err)
+	}
+	if !within {
+		t.Errorf("expected point at distance 1 to be within 5")
+	}
+
+	within, err = a.STDWithin(&far, 5)
+	if err != nil {
+		t.Fatalf("STDWithin: %v", err)
+	}
+	if within {
+		t.Errorf("expected point at distance 100 not to be within 5")
+	}
+}
+
+func TestSTDistanceZeroForPointInsidePolygon(t *testing.T) {
+	pg := mustWKT(t, "POLYGON((0 0,10 0,10 10,0 10,0 0))")
+	var pt Geometry = mustWKT(t, "POINT(5 5)")
+
+	dist, err := pg.STDistance(&pt)
+	if err != nil {
+		t.Fatalf("STDistance: %v", err)
+	}
+	if dist != 0 {
+		t.Errorf("STDistance = %v, want 0 for a point strictly inside the polygon", dist)
+	}
+
+	within, err := pg.STDWithin(&pt, 0.001)
+	if err != nil {
+		t.Fatalf("STDWithin: %v", err)
+	}
+	if !within {
+		t.Errorf("expected a point inside the polygon to be STDWithin any tolerance, including 0.001")
+	}
+}
+
+func TestSTDistanceZeroWhenIntersecting(t *testing.T) {
+	a := NewLineString([]Coordinate{{X: 0, Y: 0}, {X: 10, Y: 10}})
+	var b Geometry = NewLineString([]Coordinate{{X: 0, Y: 10}, {X: 10, Y: 0}})
+
+	dist, err := a.STDistance(&b)
+	if err != nil {
+		t.Fatalf("STDistance: %v", err)
+	}
+	if dist != 0 {
+		t.Errorf("STDistance = %v, want 0 for crossing segments", dist)
+	}
+}
@@ -0,0 +1,76 @@
+package geometry
+
+import "math"
+
+// Envelope is an axis-aligned bounding box, used by the Delaunay/Voronoi
+// constructors for picking a super-triangle / clip region. The spatial
+// index has its own index.Envelope type; bulk operators convert this one
+// into it when keying geometries into an index.STRtree.
+type Envelope struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+func envelopeOf(coords []Coordinate) Envelope {
+	e := Envelope{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+	for _, c := range coords {
+		if c.X < e.MinX {
+			e.MinX = c.X
+		}
+		if c.Y < e.MinY {
+			e.MinY = c.Y
+		}
+		if c.X > e.MaxX {
+			e.MaxX = c.X
+		}
+		if c.Y > e.MaxY {
+			e.MaxY = c.Y
+		}
+	}
+	return e
+}
+
+// Pad grows the envelope on every side by fraction * its own width/height
+// (falling back to a flat `fraction` units when that side has zero size,
+// e.g. a single point or a vertical/horizontal line).
+func (e Envelope) Pad(fraction float64) Envelope {
+	dx := (e.MaxX - e.MinX) * fraction
+	dy := (e.MaxY - e.MinY) * fraction
+	if dx == 0 {
+		dx = fraction
+	}
+	if dy == 0 {
+		dy = fraction
+	}
+	return Envelope{MinX: e.MinX - dx, MinY: e.MinY - dy, MaxX: e.MaxX + dx, MaxY: e.MaxY + dy}
+}
+
+// Bounds returns the axis-aligned bounding box of every coordinate g is
+// built from (its own position, vertices, or the recursive union of a
+// collection's members). It is the building block spatial indexes (such
+// as index.STRtree) key their nodes on.
+func Bounds(g Geometry) (Envelope, error) {
+	coords, err := extractPoints(g)
+	if err != nil {
+		return Envelope{}, err
+	}
+	if len(coords) == 0 {
+		return Envelope{}, errEmptyGeometry
+	}
+	return envelopeOf(coords), nil
+}
+
+// Intersects reports whether two envelopes overlap or touch.
+func (e Envelope) Intersects(o Envelope) bool {
+	return e.MinX <= o.MaxX && e.MaxX >= o.MinX && e.MinY <= o.MaxY && e.MaxY >= o.MinY
+}
+
+// Ring returns the envelope's four corners as a closed, counter-clockwise ring.
+func (e Envelope) Ring() []Coordinate {
+	return []Coordinate{
+		{X: e.MinX, Y: e.MinY},
+		{X: e.MaxX, Y: e.MinY},
+		{X: e.MaxX, Y: e.MaxY},
+		{X: e.MinX, Y: e.MaxY},
+		{X: e.MinX, Y: e.MinY},
+	}
+}
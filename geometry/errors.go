@@ -0,0 +1,13 @@
+package geometry
+
+import "errors"
+
+// ErrUnsupported is returned by Geometry operations that a concrete type
+// has not implemented yet. As the library grows, concrete types override
+// the embedded unsupported stubs one operation at a time instead of
+// carrying partial implementations of the interface.
+var ErrUnsupported = errors.New("geometry: operation not supported by this geometry type yet")
+
+// ErrMalformed is returned by parsers (WKT, WKB, ...) when the input does
+// not describe a valid geometry of the expected shape.
+var ErrMalformed = errors.New("geometry: malformed input")
@@ -0,0 +1,459 @@
+package geometry
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// This file implements GeoJSON (RFC 7946) marshaling/unmarshaling directly
+// on the concrete geometry types, the same way wkt.go/wkb.go implement
+// WKT/WKB inside this package rather than a separate subpackage: MarshalJSON
+// and UnmarshalJSON must live beside the type they're defined on, and a
+// subpackage importing geometry could not add methods to geometry's own
+// types. encoding/geojson builds Feature/FeatureCollection wrappers on top
+// of this.
+//
+// FromGeoJSON always produces the 2D (non-Z) variant of a type, since
+// RFC 7946 gives Point/LineString/Polygon etc. the same "type" string
+// whether a position has two or three elements. Callers who know they
+// want a *PointZ/*LineStringZ/*PolygonZ and a 3-element position can
+// json.Unmarshal directly into one instead.
+
+type pointGeoJSON struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+type lineStringGeoJSON struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+type polygonGeoJSON struct {
+	Type        string        `json:"type"`
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
+type multiPointGeoJSON struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+type multiLineStringGeoJSON struct {
+	Type        string        `json:"type"`
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
+type multiPolygonGeoJSON struct {
+	Type        string          `json:"type"`
+	Coordinates [][][][]float64 `json:"coordinates"`
+}
+
+type geometryCollectionGeoJSON struct {
+	Type       string            `json:"type"`
+	Geometries []json.RawMessage `json:"geometries"`
+}
+
+func position(c Coordinate) []float64 { return []float64{c.X, c.Y} }
+
+func positionZ(c CoordinateXYZ) []float64 { return []float64{c.X, c.Y, c.Z} }
+
+func coordinateFromPosition(pos []float64) (Coordinate, error) {
+	if len(pos) < 2 {
+		return Coordinate{}, fmt.Errorf("geometry: %w: GeoJSON position needs at least 2 elements, got %d", ErrMalformed, len(pos))
+	}
+	return Coordinate{X: pos[0], Y: pos[1]}, nil
+}
+
+func coordinateXYZFromPosition(pos []float64) (CoordinateXYZ, error) {
+	if len(pos) < 3 {
+		return CoordinateXYZ{}, fmt.Errorf("geometry: %w: GeoJSON position needs at least 3 elements for a Z variant, got %d", ErrMalformed, len(pos))
+	}
+	return CoordinateXYZ{X: pos[0], Y: pos[1], Z: pos[2]}, nil
+}
+
+func positions(coords []Coordinate) [][]float64 {
+	out := make([][]float64, len(coords))
+	for i, c := range coords {
+		out[i] = position(c)
+	}
+	return out
+}
+
+func coordinatesFromPositions(pos [][]float64) ([]Coordinate, error) {
+	out := make([]Coordinate, len(pos))
+	for i, p := range pos {
+		c, err := coordinateFromPosition(p)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = c
+	}
+	return out, nil
+}
+
+func ringPositions(rings [][]Coordinate) [][][]float64 {
+	out := make([][][]float64, len(rings))
+	for i, r := range rings {
+		out[i] = positions(r)
+	}
+	return out
+}
+
+func coordinateRingsFromPositions(rings [][][]float64) ([][]Coordinate, error) {
+	out := make([][]Coordinate, len(rings))
+	for i, r := range rings {
+		ring, err := coordinatesFromPositions(r)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = ring
+	}
+	return out, nil
+}
+
+func (p *Point) MarshalJSON() ([]byte, error) {
+	coords := []float64{}
+	if !p.Empty {
+		coords = position(p.Coordinate)
+	}
+	return json.Marshal(pointGeoJSON{Type: "Point", Coordinates: coords})
+}
+
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var g pointGeoJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "Point" {
+		return fmt.Errorf("geometry: %w: expected GeoJSON type \"Point\", got %q", ErrMalformed, g.Type)
+	}
+	if len(g.Coordinates) == 0 {
+		*p = Point{Empty: true}
+		return nil
+	}
+	c, err := coordinateFromPosition(g.Coordinates)
+	if err != nil {
+		return err
+	}
+	*p = Point{Coordinate: c}
+	return nil
+}
+
+func (l *LineString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(lineStringGeoJSON{Type: "LineString", Coordinates: positions(l.Points)})
+}
+
+func (l *LineString) UnmarshalJSON(data []byte) error {
+	var g lineStringGeoJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "LineString" {
+		return fmt.Errorf("geometry: %w: expected GeoJSON type \"LineString\", got %q", ErrMalformed, g.Type)
+	}
+	points, err := coordinatesFromPositions(g.Coordinates)
+	if err != nil {
+		return err
+	}
+	l.Points = points
+	return nil
+}
+
+func (pg *Polygon) MarshalJSON() ([]byte, error) {
+	rings := append([][]Coordinate{pg.Shell}, pg.Holes...)
+	if len(pg.Shell) == 0 {
+		rings = nil
+	}
+	return json.Marshal(polygonGeoJSON{Type: "Polygon", Coordinates: ringPositions(rings)})
+}
+
+func (pg *Polygon) UnmarshalJSON(data []byte) error {
+	var g polygonGeoJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "Polygon" {
+		return fmt.Errorf("geometry: %w: expected GeoJSON type \"Polygon\", got %q", ErrMalformed, g.Type)
+	}
+	rings, err := coordinateRingsFromPositions(g.Coordinates)
+	if err != nil {
+		return err
+	}
+	if len(rings) == 0 {
+		pg.Shell, pg.Holes = nil, nil
+		return nil
+	}
+	pg.Shell, pg.Holes = rings[0], rings[1:]
+	return nil
+}
+
+func (mp *MultiPoint) MarshalJSON() ([]byte, error) {
+	coords := make([][]float64, len(mp.Points))
+	for i, p := range mp.Points {
+		coords[i] = []float64{}
+		if !p.Empty {
+			coords[i] = position(p.Coordinate)
+		}
+	}
+	return json.Marshal(multiPointGeoJSON{Type: "MultiPoint", Coordinates: coords})
+}
+
+func (mp *MultiPoint) UnmarshalJSON(data []byte) error {
+	var g multiPointGeoJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "MultiPoint" {
+		return fmt.Errorf("geometry: %w: expected GeoJSON type \"MultiPoint\", got %q", ErrMalformed, g.Type)
+	}
+	points := make([]*Point, len(g.Coordinates))
+	for i, pos := range g.Coordinates {
+		if len(pos) == 0 {
+			points[i] = &Point{Empty: true}
+			continue
+		}
+		c, err := coordinateFromPosition(pos)
+		if err != nil {
+			return err
+		}
+		points[i] = &Point{Coordinate: c}
+	}
+	mp.Points = points
+	return nil
+}
+
+func (ml *MultiLineString) MarshalJSON() ([]byte, error) {
+	lines := make([][]Coordinate, len(ml.Lines))
+	for i, l := range ml.Lines {
+		lines[i] = l.Points
+	}
+	return json.Marshal(multiLineStringGeoJSON{Type: "MultiLineString", Coordinates: ringPositions(lines)})
+}
+
+func (ml *MultiLineString) UnmarshalJSON(data []byte) error {
+	var g multiLineStringGeoJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "MultiLineString" {
+		return fmt.Errorf("geometry: %w: expected GeoJSON type \"MultiLineString\", got %q", ErrMalformed, g.Type)
+	}
+	lineCoords, err := coordinateRingsFromPositions(g.Coordinates)
+	if err != nil {
+		return err
+	}
+	lines := make([]*LineString, len(lineCoords))
+	for i, pts := range lineCoords {
+		lines[i] = NewLineString(pts)
+	}
+	ml.Lines = lines
+	return nil
+}
+
+func (mp *MultiPolygon) MarshalJSON() ([]byte, error) {
+	polys := make([][][][]float64, len(mp.Polygons))
+	for i, pg := range mp.Polygons {
+		rings := append([][]Coordinate{pg.Shell}, pg.Holes...)
+		if len(pg.Shell) == 0 {
+			rings = nil
+		}
+		polys[i] = ringPositions(rings)
+	}
+	return json.Marshal(multiPolygonGeoJSON{Type: "MultiPolygon", Coordinates: polys})
+}
+
+func (mp *MultiPolygon) UnmarshalJSON(data []byte) error {
+	var g multiPolygonGeoJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "MultiPolygon" {
+		return fmt.Errorf("geometry: %w: expected GeoJSON type \"MultiPolygon\", got %q", ErrMalformed, g.Type)
+	}
+	polys := make([]*Polygon, len(g.Coordinates))
+	for i, ringsRaw := range g.Coordinates {
+		rings, err := coordinateRingsFromPositions(ringsRaw)
+		if err != nil {
+			return err
+		}
+		if len(rings) == 0 {
+			polys[i] = NewPolygon(nil, nil)
+			continue
+		}
+		polys[i] = NewPolygon(rings[0], rings[1:])
+	}
+	mp.Polygons = polys
+	return nil
+}
+
+func (gc *GeometryCollection) MarshalJSON() ([]byte, error) {
+	raws := make([]json.RawMessage, len(gc.Geometries))
+	for i, sub := range gc.Geometries {
+		m, ok := sub.(json.Marshaler)
+		if !ok {
+			return nil, fmt.Errorf("geometry: %w: GeometryCollection member does not support GeoJSON marshaling", ErrUnsupported)
+		}
+		b, err := m.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		raws[i] = b
+	}
+	return json.Marshal(geometryCollectionGeoJSON{Type: "GeometryCollection", Geometries: raws})
+}
+
+func (gc *GeometryCollection) UnmarshalJSON(data []byte) error {
+	var g geometryCollectionGeoJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "GeometryCollection" {
+		return fmt.Errorf("geometry: %w: expected GeoJSON type \"GeometryCollection\", got %q", ErrMalformed, g.Type)
+	}
+	geoms := make([]Geometry, len(g.Geometries))
+	for i, raw := range g.Geometries {
+		sub, err := FromGeoJSON(raw)
+		if err != nil {
+			return err
+		}
+		geoms[i] = sub
+	}
+	gc.Geometries = geoms
+	return nil
+}
+
+// FromGeoJSON parses a GeoJSON Geometry object (not a Feature or
+// FeatureCollection; see encoding/geojson for those) into the matching
+// concrete Geometry implementation.
+func FromGeoJSON(data []byte) (Geometry, error) {
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+	var geom Geometry
+	switch head.Type {
+	case "Point":
+		geom = &Point{}
+	case "LineString":
+		geom = &LineString{}
+	case "Polygon":
+		geom = &Polygon{}
+	case "MultiPoint":
+		geom = &MultiPoint{}
+	case "MultiLineString":
+		geom = &MultiLineString{}
+	case "MultiPolygon":
+		geom = &MultiPolygon{}
+	case "GeometryCollection":
+		geom = &GeometryCollection{}
+	default:
+		return nil, fmt.Errorf("geometry: %w: unknown GeoJSON type %q", ErrMalformed, head.Type)
+	}
+	if err := geom.(json.Unmarshaler).UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return geom, nil
+}
+
+func (p *PointZ) MarshalJSON() ([]byte, error) {
+	coords := []float64{}
+	if !p.Empty {
+		coords = positionZ(p.CoordinateXYZ)
+	}
+	return json.Marshal(pointGeoJSON{Type: "Point", Coordinates: coords})
+}
+
+func (p *PointZ) UnmarshalJSON(data []byte) error {
+	var g pointGeoJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "Point" {
+		return fmt.Errorf("geometry: %w: expected GeoJSON type \"Point\", got %q", ErrMalformed, g.Type)
+	}
+	if len(g.Coordinates) == 0 {
+		*p = PointZ{Empty: true}
+		return nil
+	}
+	c, err := coordinateXYZFromPosition(g.Coordinates)
+	if err != nil {
+		return err
+	}
+	*p = PointZ{CoordinateXYZ: c}
+	return nil
+}
+
+func (l *LineStringZ) MarshalJSON() ([]byte, error) {
+	coords := make([][]float64, len(l.Points))
+	for i, c := range l.Points {
+		coords[i] = positionZ(c)
+	}
+	return json.Marshal(lineStringGeoJSON{Type: "LineString", Coordinates: coords})
+}
+
+func (l *LineStringZ) UnmarshalJSON(data []byte) error {
+	var g lineStringGeoJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "LineString" {
+		return fmt.Errorf("geometry: %w: expected GeoJSON type \"LineString\", got %q", ErrMalformed, g.Type)
+	}
+	points := make([]CoordinateXYZ, len(g.Coordinates))
+	for i, pos := range g.Coordinates {
+		c, err := coordinateXYZFromPosition(pos)
+		if err != nil {
+			return err
+		}
+		points[i] = c
+	}
+	l.Points = points
+	return nil
+}
+
+func (pg *PolygonZ) MarshalJSON() ([]byte, error) {
+	rings := append([][]CoordinateXYZ{pg.Shell}, pg.Holes...)
+	if len(pg.Shell) == 0 {
+		rings = nil
+	}
+	polyCoords := make([][][]float64, len(rings))
+	for i, ring := range rings {
+		ringCoords := make([][]float64, len(ring))
+		for j, c := range ring {
+			ringCoords[j] = positionZ(c)
+		}
+		polyCoords[i] = ringCoords
+	}
+	return json.Marshal(polygonGeoJSON{Type: "Polygon", Coordinates: polyCoords})
+}
+
+func (pg *PolygonZ) UnmarshalJSON(data []byte) error {
+	var g polygonGeoJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "Polygon" {
+		return fmt.Errorf("geometry: %w: expected GeoJSON type \"Polygon\", got %q", ErrMalformed, g.Type)
+	}
+	rings := make([][]CoordinateXYZ, len(g.Coordinates))
+	for i, ringRaw := range g.Coordinates {
+		ring := make([]CoordinateXYZ, len(ringRaw))
+		for j, pos := range ringRaw {
+			c, err := coordinateXYZFromPosition(pos)
+			if err != nil {
+				return err
+			}
+			ring[j] = c
+		}
+		rings[i] = ring
+	}
+	if len(rings) == 0 {
+		pg.Shell, pg.Holes = nil, nil
+		return nil
+	}
+	pg.Shell, pg.Holes = rings[0], rings[1:]
+	return nil
+}
@@ -0,0 +1,62 @@
+package geometry
+
+import "testing"
+
+func TestGeoJSONRoundTrip(t *testing.T) {
+	cases := []string{
+		"POINT (1 2)",
+		"LINESTRING (0 0, 1 1, 2 2)",
+		"POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))",
+		"MULTIPOINT ((0 0), (1 1))",
+		"MULTILINESTRING ((0 0, 1 1), (2 2, 3 3))",
+		"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)), ((2 2, 3 2, 3 3, 2 3, 2 2)))",
+		"GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (0 0, 1 1))",
+	}
+	for _, wkt := range cases {
+		g, err := FromWKT(wkt)
+		if err != nil {
+			t.Fatalf("FromWKT(%q): %v", wkt, err)
+		}
+		m, ok := g.(interface{ MarshalJSON() ([]byte, error) })
+		if !ok {
+			t.Fatalf("%T does not implement MarshalJSON", g)
+		}
+		data, err := m.MarshalJSON()
+		if err != nil {
+			t.Fatalf("MarshalJSON for %q: %v", wkt, err)
+		}
+		back, err := FromGeoJSON(data)
+		if err != nil {
+			t.Fatalf("FromGeoJSON for %q: %v", wkt, err)
+		}
+		gotWKT, err := back.AsText()
+		if err != nil {
+			t.Fatalf("AsText after GeoJSON round trip for %q: %v", wkt, err)
+		}
+		if gotWKT != wkt {
+			t.Errorf("GeoJSON round trip mismatch: got %q, want %q", gotWKT, wkt)
+		}
+	}
+}
+
+func TestFromGeoJSONUnknownType(t *testing.T) {
+	_, err := FromGeoJSON([]byte(`{"type":"NotAThing","coordinates":[1,2]}`))
+	if err == nil {
+		t.Errorf("expected error for unknown GeoJSON type")
+	}
+}
+
+func TestPointZGeoJSONRoundTrip(t *testing.T) {
+	pz := NewPointZ(1, 2, 3)
+	data, err := pz.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+	var back PointZ
+	if err := back.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	if back.X != 1 || back.Y != 2 || back.Z != 3 {
+		t.Errorf("round trip = %+v, want (1, 2, 3)", back.CoordinateXYZ)
+	}
+}
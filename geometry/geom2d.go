@@ -0,0 +1,299 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+)
+
+const epsilon = 1e-9
+
+// orientation returns >0 if a,b,c turn counter-clockwise, <0 if clockwise,
+// and 0 if the three points are collinear.
+func orientation(a, b, c Coordinate) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+func sign(v float64) int {
+	switch {
+	case v > epsilon:
+		return 1
+	case v < -epsilon:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// onSegment reports whether p lies on the closed segment a-b, assuming p
+// is already known (or suspected) to be collinear with a and b.
+func onSegment(p, a, b Coordinate) bool {
+	if sign(orientation(a, b, p)) != 0 {
+		return false
+	}
+	return p.X >= math.Min(a.X, b.X)-epsilon && p.X <= math.Max(a.X, b.X)+epsilon &&
+		p.Y >= math.Min(a.Y, b.Y)-epsilon && p.Y <= math.Max(a.Y, b.Y)+epsilon
+}
+
+func coordEqual(a, b Coordinate) bool {
+	return math.Abs(a.X-b.X) < epsilon && math.Abs(a.Y-b.Y) < epsilon
+}
+
+// segXKind classifies how two segments relate to one another.
+type segXKind int
+
+const (
+	segNone    segXKind = iota // disjoint
+	segTouch                   // meet at a single point that is an endpoint of at least one segment
+	segCross                   // proper transversal crossing through both segments' interiors
+	segOverlap                 // collinear and overlapping along a positive-length sub-segment
+)
+
+// segmentIntersect classifies the intersection of segment a1-a2 with b1-b2.
+func segmentIntersect(a1, a2, b1, b2 Coordinate) segXKind {
+	d1 := sign(orientation(b1, b2, a1))
+	d2 := sign(orientation(b1, b2, a2))
+	d3 := sign(orientation(a1, a2, b1))
+	d4 := sign(orientation(a1, a2, b2))
+
+	if d1 != 0 && d2 != 0 && d3 != 0 && d4 != 0 {
+		if d1 != d2 && d3 != d4 {
+			return segCross
+		}
+		return segNone
+	}
+
+	if d1 == 0 && d2 == 0 && d3 == 0 && d4 == 0 {
+		return collinearOverlap(a1, a2, b1, b2)
+	}
+
+	if (d1 == 0 && onSegment(a1, b1, b2)) ||
+		(d2 == 0 && onSegment(a2, b1, b2)) ||
+		(d3 == 0 && onSegment(b1, a1, a2)) ||
+		(d4 == 0 && onSegment(b2, a1, a2)) {
+		return segTouch
+	}
+	return segNone
+}
+
+// collinearOverlap assumes the four points are collinear and determines
+// whether segment a1-a2 and b1-b2 share a single point, a positive-length
+// sub-segment, or nothing.
+func collinearOverlap(a1, a2, b1, b2 Coordinate) segXKind {
+	// Parametrize along the dominant axis of a1-a2.
+	dx, dy := a2.X-a1.X, a2.Y-a1.Y
+	var param func(c Coordinate) float64
+	if math.Abs(dx) >= math.Abs(dy) {
+		if dx == 0 {
+			return segNone // a1 == a2, degenerate
+		}
+		param = func(c Coordinate) float64 { return (c.X - a1.X) / dx }
+	} else {
+		param = func(c Coordinate) float64 { return (c.Y - a1.Y) / dy }
+	}
+
+	aLo, aHi := 0.0, 1.0
+	bLo, bHi := param(b1), param(b2)
+	if bLo > bHi {
+		bLo, bHi = bHi, bLo
+	}
+
+	lo := math.Max(aLo, bLo)
+	hi := math.Min(aHi, bHi)
+	if lo > hi+epsilon {
+		return segNone
+	}
+	if hi-lo > epsilon {
+		return segOverlap
+	}
+	return segTouch
+}
+
+// ringSegments returns the closed sequence of edges making up ring,
+// implicitly closing it if the caller did not repeat the first point.
+func ringSegments(ring []Coordinate) [][2]Coordinate {
+	n := len(ring)
+	if n < 2 {
+		return nil
+	}
+	closed := coordEqual(ring[0], ring[n-1])
+	count := n - 1
+	if !closed {
+		count = n
+	}
+	segs := make([][2]Coordinate, count)
+	for i := 0; i < count; i++ {
+		segs[i] = [2]Coordinate{ring[i], ring[(i+1)%n]}
+	}
+	return segs
+}
+
+// openSegments returns the (non-wrapping) edges of an open polyline such
+// as a LineString.
+func openSegments(points []Coordinate) [][2]Coordinate {
+	if len(points) < 2 {
+		return nil
+	}
+	segs := make([][2]Coordinate, len(points)-1)
+	for i := 0; i < len(points)-1; i++ {
+		segs[i] = [2]Coordinate{points[i], points[i+1]}
+	}
+	return segs
+}
+
+// rayCastInRing reports whether c lies strictly inside ring using the
+// standard even-odd ray casting rule. Callers are expected to have already
+// ruled out c lying exactly on the ring's boundary.
+func rayCastInRing(c Coordinate, ring []Coordinate) bool {
+	inside := false
+	n := len(ring)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		pi, pj := ring[i], ring[j]
+		if (pi.Y > c.Y) != (pj.Y > c.Y) {
+			xIntersect := pj.X + (c.Y-pj.Y)/(pi.Y-pj.Y)*(pi.X-pj.X)
+			if c.X < xIntersect {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+func pointOnRing(c Coordinate, ring []Coordinate) bool {
+	for _, seg := range ringSegments(ring) {
+		if onSegment(c, seg[0], seg[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// pointInPolygon classifies c as interior ('I'), boundary ('B') or
+// exterior ('E') of the polygon described by shell and holes.
+func pointInPolygon(c Coordinate, shell []Coordinate, holes [][]Coordinate) byte {
+	if len(shell) == 0 {
+		return 'E'
+	}
+	if pointOnRing(c, shell) {
+		return 'B'
+	}
+	for _, h := range holes {
+		if pointOnRing(c, h) {
+			return 'B'
+		}
+	}
+	if !rayCastInRing(c, shell) {
+		return 'E'
+	}
+	for _, h := range holes {
+		if rayCastInRing(c, h) {
+			return 'E'
+		}
+	}
+	return 'I'
+}
+
+func lerp(a, b Coordinate, t float64) Coordinate {
+	return Coordinate{X: a.X + (b.X-a.X)*t, Y: a.Y + (b.Y-a.Y)*t}
+}
+
+// paramOf projects p (assumed collinear with a-b) onto a-b, returning the
+// parameter t such that lerp(a, b, t) == p.
+func paramOf(a, b, p Coordinate) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	if math.Abs(dx) >= math.Abs(dy) {
+		if dx == 0 {
+			return 0
+		}
+		return (p.X - a.X) / dx
+	}
+	if dy == 0 {
+		return 0
+	}
+	return (p.Y - a.Y) / dy
+}
+
+func clamp01(t float64) float64 {
+	if t < 0 {
+		return 0
+	}
+	if t > 1 {
+		return 1
+	}
+	return t
+}
+
+// segmentBreakParams returns the parameter(s) t along a1-a2 (in [0,1]) at
+// which segment b1-b2 intersects it, used to chop a segment at every
+// point it crosses another geometry's boundary.
+func segmentBreakParams(a1, a2, b1, b2 Coordinate) []float64 {
+	d1 := sign(orientation(b1, b2, a1))
+	d2 := sign(orientation(b1, b2, a2))
+	d3 := sign(orientation(a1, a2, b1))
+	d4 := sign(orientation(a1, a2, b2))
+
+	if d1 != 0 && d2 != 0 && d3 != 0 && d4 != 0 {
+		if d1 != d2 && d3 != d4 {
+			return []float64{clamp01(crossParam(a1, a2, b1, b2))}
+		}
+		return nil
+	}
+
+	if d1 == 0 && d2 == 0 && d3 == 0 && d4 == 0 {
+		t1, t2 := paramOf(a1, a2, b1), paramOf(a1, a2, b2)
+		var out []float64
+		if t1 >= -epsilon && t1 <= 1+epsilon {
+			out = append(out, clamp01(t1))
+		}
+		if t2 >= -epsilon && t2 <= 1+epsilon {
+			out = append(out, clamp01(t2))
+		}
+		return out
+	}
+
+	var out []float64
+	if d3 == 0 && onSegment(b1, a1, a2) {
+		out = append(out, clamp01(paramOf(a1, a2, b1)))
+	}
+	if d4 == 0 && onSegment(b2, a1, a2) {
+		out = append(out, clamp01(paramOf(a1, a2, b2)))
+	}
+	return out
+}
+
+// crossParam returns the parameter t along a1-a2 where it properly
+// crosses b1-b2, assuming the two segments are known to cross.
+func crossParam(a1, a2, b1, b2 Coordinate) float64 {
+	denom := (a2.X-a1.X)*(b2.Y-b1.Y) - (a2.Y-a1.Y)*(b2.X-b1.X)
+	if denom == 0 {
+		return 0
+	}
+	return ((b1.X-a1.X)*(b2.Y-b1.Y) - (b1.Y-a1.Y)*(b2.X-b1.X)) / denom
+}
+
+func sortedUnique(vals []float64) []float64 {
+	sort.Float64s(vals)
+	out := vals[:0]
+	for i, v := range vals {
+		if i == 0 || v-out[len(out)-1] > epsilon {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// pointOnLineString classifies c against an (open) LineString.
+func pointOnLineString(c Coordinate, points []Coordinate) byte {
+	if len(points) == 0 {
+		return 'E'
+	}
+	closed := len(points) > 1 && coordEqual(points[0], points[len(points)-1])
+	if !closed && (coordEqual(c, points[0]) || coordEqual(c, points[len(points)-1])) {
+		return 'B'
+	}
+	for _, seg := range openSegments(points) {
+		if onSegment(c, seg[0], seg[1]) {
+			return 'I'
+		}
+	}
+	return 'E'
+}
@@ -1,20 +1,25 @@
 package geometry
 
+// Geometry type tags, as used by OGC well-known text/binary. These name the
+// *kind* of geometry a WKT/WKB-style payload encodes; they are distinct from
+// the concrete Go struct types (Point, LineString, ...) declared in
+// types.go, which is why they carry a Type prefix rather than sharing the
+// bare name.
 const (
-	Point      = "Point"
-	LineString = "LineString"
-	Polygon    = "Polygon"
-
-	MultiPoint      = "MultiPoint"
-	MultiLineString = "MultiLineString"
-	MultiPolygon    = "MultiPolygon"
-
-	CircularString               = "CircularString"
-	CompountCurve                = "CompoundCurve"
-	CurvePolygon                 = "CurvePolygon"
-	PolyhedralSurface            = "PolyhedralSurface"
-	TriangulatedIrregularNetwork = "TriangulatedIrregularNetwork"
-	Triangle                     = "Triangle"
+	TypePoint      = "Point"
+	TypeLineString = "LineString"
+	TypePolygon    = "Polygon"
+
+	TypeMultiPoint      = "MultiPoint"
+	TypeMultiLineString = "MultiLineString"
+	TypeMultiPolygon    = "MultiPolygon"
+
+	TypeCircularString               = "CircularString"
+	TypeCompountCurve                = "CompoundCurve"
+	TypeCurvePolygon                 = "CurvePolygon"
+	TypePolyhedralSurface            = "PolyhedralSurface"
+	TypeTriangulatedIrregularNetwork = "TriangulatedIrregularNetwork"
+	TypeTriangle                     = "Triangle"
 )
 
 type Geometry interface {
@@ -58,10 +63,35 @@ type Geometry interface {
 	// that dimension. and each has at least one point inside the other.
 	STOverlaps(g *Geometry) (bool, error)
 
+	// STRelate computes the DE-9IM intersection matrix between two
+	// geometries: a 9-character string describing, for each combination of
+	// Interior/Boundary/Exterior of A against Interior/Boundary/Exterior of
+	// B, the dimension of their intersection ('0', '1', '2') or 'F' if it
+	// is empty.
+	STRelate(g *Geometry) (string, error)
+
+	// STRelatePattern evaluates the DE-9IM intersection matrix of two
+	// geometries against a 9-character pattern (using 'T', 'F', '0', '1',
+	// '2' and '*' as wildcard), as produced e.g. by STRelate.
+	STRelatePattern(g *Geometry, pattern string) (bool, error)
+
+	// Returns TRUE if no point of B is outside geometry A, i.e. B is
+	// covered by A. Unlike STContains, STCovers allows B's boundary to lie
+	// entirely on A's boundary.
+	STCovers(g *Geometry) (bool, error)
+
+	// Returns TRUE if no point of A is outside geometry B, i.e. A is
+	// covered by B. The inverse of STCovers.
+	STCoveredBy(g *Geometry) (bool, error)
+
 	// For geometry types returns the minimum 2D Cartesian (planar) distance
 	// between two geometries, in projected units (spatial ref units).
 	STDistance(g *Geometry) (float64, error)
 
+	// STDWithin returns TRUE if the 2D Cartesian distance between two
+	// geometries is less than or equal to distance.
+	STDWithin(g *Geometry, distance float64) (bool, error)
+
 	// Returns the area of a polygonal geometry.
 	STArea() (float64, error)
 
@@ -125,11 +155,48 @@ type Geometry interface {
 	// The result may not be valid even if the input is.
 	STSimplify(tolerence int) (Geometry, error)
 
-	// Future Support
-	// STRelate
-	// STSnapToGrid
-	// STTranslate
-	// STScale
-	// STRotate
-	// STAffine
+	// AsText returns the Well-Known Text (WKT) representation of the geometry.
+	AsText() (string, error)
+
+	// AsBinary returns the Well-Known Binary (WKB) representation of the geometry.
+	AsBinary() ([]byte, error)
+
+	// ST3DIntersects returns true if the two geometries share any point in
+	// true 3D space (as opposed to STIntersects, which only considers the
+	// planar XY projection). Geometries without a Z ordinate are handled
+	// according to DefaultZPolicy.
+	ST3DIntersects(g *Geometry) (bool, error)
+
+	// ST3DDistance returns the minimum Euclidean distance between two
+	// geometries in 3-space. Geometries without a Z ordinate are handled
+	// according to DefaultZPolicy.
+	ST3DDistance(g *Geometry) (float64, error)
+
+	// ST3DLength returns the 3D length of a LineString-like geometry.
+	ST3DLength() (float64, error)
+
+	// STZMin returns the minimum Z ordinate found in the geometry.
+	STZMin() (float64, error)
+
+	// STZMax returns the maximum Z ordinate found in the geometry.
+	STZMax() (float64, error)
+
+	// STTranslate returns a copy of the geometry shifted by (dx, dy).
+	STTranslate(dx, dy float64) Geometry
+
+	// STScale returns a copy of the geometry scaled by (sx, sy) about the origin.
+	STScale(sx, sy float64) Geometry
+
+	// STRotate returns a copy of the geometry rotated by radians (counter-
+	// clockwise) about origin, or about (0, 0) if origin is nil.
+	STRotate(radians float64, origin *Point) Geometry
+
+	// STAffine applies the 2D affine transform [[a b] [d e]] + (xoff,
+	// yoff) to every coordinate of the geometry.
+	STAffine(a, b, d, e, xoff, yoff float64) Geometry
+
+	// STSnapToGrid returns a copy of the geometry with every coordinate
+	// quantized to the nearest multiple of size, collapsing any
+	// consecutive coordinates that snap to the same point.
+	STSnapToGrid(size float64) Geometry
 }
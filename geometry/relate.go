@@ -0,0 +1,592 @@
+package geometry
+
+import (
+	"fmt"
+	"strings"
+)
+
+// This file implements the DE-9IM (Dimensionally Extended 9-Intersection
+// Model) relate matrix and the predicates derived from it, following the
+// OGC Simple Features / PostGIS semantics. Every boolean predicate below
+// (STEquals, STDisjoint, ...) is computed from the same STRelate matrix
+// rather than its own bespoke geometric test.
+//
+// Support currently covers Point, LineString and Polygon. Multi*/
+// GeometryCollection relate support is left unimplemented (ErrUnsupported)
+// until those types grow their own interior/boundary decomposition.
+//
+// The interior/interior/boundary overlap tests for Polygon-vs-Polygon use
+// a vertex + boundary-crossing heuristic rather than full polygon
+// clipping; it is correct for the simple, non-self-intersecting polygons
+// the rest of this package assumes, but can under-report area overlap in
+// pathological cases (e.g. B's interior sitting entirely inside a concave
+// pocket of A without any vertex of either polygon lying inside the
+// other). Likewise, exterior-intersection cells ("does part of X escape
+// Y") are decided by sampling X's vertices/segment midpoints rather than
+// an exact boundary traversal.
+
+type partKind int
+
+const (
+	partEmpty partKind = iota
+	partPoints
+	partCurve
+	partSurface
+)
+
+type polyShape struct {
+	shell []Coordinate
+	holes [][]Coordinate
+}
+
+type part struct {
+	kind     partKind
+	points   []Coordinate
+	curve    [][2]Coordinate
+	surfaces []polyShape
+}
+
+func pointsPart(pts []Coordinate) part {
+	if len(pts) == 0 {
+		return part{kind: partEmpty}
+	}
+	return part{kind: partPoints, points: pts}
+}
+
+func curvePart(segs [][2]Coordinate) part {
+	if len(segs) == 0 {
+		return part{kind: partEmpty}
+	}
+	return part{kind: partCurve, curve: segs}
+}
+
+func surfacePart(shapes []polyShape) part {
+	if len(shapes) == 0 || len(shapes[0].shell) == 0 {
+		return part{kind: partEmpty}
+	}
+	return part{kind: partSurface, surfaces: shapes}
+}
+
+// partedGeometry is implemented by every concrete Geometry that knows how
+// to decompose itself into an interior part and a boundary part for the
+// purposes of relate().
+type partedGeometry interface {
+	Geometry
+	interiorPart() part
+	boundaryPart() part
+}
+
+func (p *Point) interiorPart() part {
+	if p.Empty {
+		return part{kind: partEmpty}
+	}
+	return pointsPart([]Coordinate{p.Coordinate})
+}
+
+func (p *Point) boundaryPart() part { return part{kind: partEmpty} } // a point has no boundary
+
+func (l *LineString) interiorPart() part { return curvePart(openSegments(l.Points)) }
+
+func (l *LineString) boundaryPart() part {
+	n := len(l.Points)
+	if n < 2 || coordEqual(l.Points[0], l.Points[n-1]) {
+		return part{kind: partEmpty} // closed rings have no boundary
+	}
+	return pointsPart([]Coordinate{l.Points[0], l.Points[n-1]})
+}
+
+func (pg *Polygon) interiorPart() part {
+	return surfacePart([]polyShape{{shell: pg.Shell, holes: pg.Holes}})
+}
+
+func (pg *Polygon) boundaryPart() part {
+	if len(pg.Shell) == 0 {
+		return part{kind: partEmpty}
+	}
+	segs := ringSegments(pg.Shell)
+	for _, h := range pg.Holes {
+		segs = append(segs, ringSegments(h)...)
+	}
+	return curvePart(segs)
+}
+
+func boundarySegments(s polyShape) [][2]Coordinate {
+	segs := ringSegments(s.shell)
+	for _, h := range s.holes {
+		segs = append(segs, ringSegments(h)...)
+	}
+	return segs
+}
+
+func shapeVertices(s polyShape) []Coordinate {
+	pts := append([]Coordinate{}, s.shell...)
+	for _, h := range s.holes {
+		pts = append(pts, h...)
+	}
+	return pts
+}
+
+func midpoint(a, b Coordinate) Coordinate {
+	return Coordinate{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+}
+
+// intersectionDimension returns the dimension ('F','0','1','2') of the
+// intersection of two parts.
+func intersectionDimension(a, b part) byte {
+	switch {
+	case a.kind == partEmpty || b.kind == partEmpty:
+		return 'F'
+	case a.kind == partPoints && b.kind == partPoints:
+		return dimPointsPoints(a.points, b.points)
+	case a.kind == partPoints && b.kind == partCurve:
+		return dimPointsCurve(a.points, b.curve)
+	case a.kind == partCurve && b.kind == partPoints:
+		return dimPointsCurve(b.points, a.curve)
+	case a.kind == partPoints && b.kind == partSurface:
+		return dimPointsSurface(a.points, b.surfaces)
+	case a.kind == partSurface && b.kind == partPoints:
+		return dimPointsSurface(b.points, a.surfaces)
+	case a.kind == partCurve && b.kind == partCurve:
+		return dimCurveCurve(a.curve, b.curve)
+	case a.kind == partCurve && b.kind == partSurface:
+		return dimCurveSurface(a.curve, b.surfaces)
+	case a.kind == partSurface && b.kind == partCurve:
+		return dimCurveSurface(b.curve, a.surfaces)
+	default: // partSurface vs partSurface
+		return dimSurfaceSurface(a.surfaces, b.surfaces)
+	}
+}
+
+func dimPointsPoints(a, b []Coordinate) byte {
+	for _, p := range a {
+		for _, q := range b {
+			if coordEqual(p, q) {
+				return '0'
+			}
+		}
+	}
+	return 'F'
+}
+
+func dimPointsCurve(points []Coordinate, curve [][2]Coordinate) byte {
+	for _, p := range points {
+		for _, seg := range curve {
+			if onSegment(p, seg[0], seg[1]) {
+				return '0'
+			}
+		}
+	}
+	return 'F'
+}
+
+func dimPointsSurface(points []Coordinate, surfaces []polyShape) byte {
+	for _, p := range points {
+		for _, s := range surfaces {
+			if pointInPolygon(p, s.shell, s.holes) == 'I' {
+				return '0'
+			}
+		}
+	}
+	return 'F'
+}
+
+func dimCurveCurve(a, b [][2]Coordinate) byte {
+	foundPoint := false
+	for _, sa := range a {
+		for _, sb := range b {
+			switch segmentIntersect(sa[0], sa[1], sb[0], sb[1]) {
+			case segOverlap:
+				return '1'
+			case segTouch, segCross:
+				foundPoint = true
+			}
+		}
+	}
+	if foundPoint {
+		return '0'
+	}
+	return 'F'
+}
+
+func dimCurveSurface(curve [][2]Coordinate, surfaces []polyShape) byte {
+	for _, seg := range curve {
+		breaks := []float64{0, 1}
+		for _, s := range surfaces {
+			for _, bseg := range boundarySegments(s) {
+				breaks = append(breaks, segmentBreakParams(seg[0], seg[1], bseg[0], bseg[1])...)
+			}
+		}
+		breaks = sortedUnique(breaks)
+		for i := 0; i < len(breaks)-1; i++ {
+			t0, t1 := breaks[i], breaks[i+1]
+			if t1-t0 < epsilon {
+				continue
+			}
+			mid := lerp(seg[0], seg[1], (t0+t1)/2)
+			for _, s := range surfaces {
+				if pointInPolygon(mid, s.shell, s.holes) == 'I' {
+					return '1'
+				}
+			}
+		}
+	}
+	return 'F'
+}
+
+// dimSurfaceSurface computes the II (interior/interior) cell for two
+// polygons. It must not be conflated with BB (boundary/boundary), which
+// dimCurveCurve already computes correctly from each polygon's
+// boundaryPart: two polygons that only share a boundary edge or touch at
+// a point have disjoint interiors (II = 'F') even though their
+// boundaries overlap or touch in BB. The only ways interiors can meet
+// are a vertex of one strictly inside the other, or the boundaries
+// crossing transversally (which necessarily opens overlapping area on
+// both sides of the crossing).
+func dimSurfaceSurface(as, bs []polyShape) byte {
+	for _, sa := range as {
+		for _, v := range shapeVertices(sa) {
+			for _, sb := range bs {
+				if pointInPolygon(v, sb.shell, sb.holes) == 'I' {
+					return '2'
+				}
+			}
+		}
+	}
+	for _, sb := range bs {
+		for _, v := range shapeVertices(sb) {
+			for _, sa := range as {
+				if pointInPolygon(v, sa.shell, sa.holes) == 'I' {
+					return '2'
+				}
+			}
+		}
+	}
+
+	for _, sa := range as {
+		for _, sega := range boundarySegments(sa) {
+			for _, sb := range bs {
+				for _, segb := range boundarySegments(sb) {
+					if segmentIntersect(sega[0], sega[1], segb[0], segb[1]) == segCross {
+						return '2' // boundaries crossing transversally implies overlapping area
+					}
+				}
+			}
+		}
+	}
+	return 'F'
+}
+
+// sampleOf returns representative points of a part, used to test whether
+// any of it escapes another geometry (for the exterior-intersection cells
+// of the relate matrix).
+func sampleOf(p part) []Coordinate {
+	switch p.kind {
+	case partPoints:
+		return p.points
+	case partCurve:
+		var pts []Coordinate
+		for _, seg := range p.curve {
+			pts = append(pts, seg[0], seg[1], midpoint(seg[0], seg[1]))
+		}
+		return pts
+	case partSurface:
+		var pts []Coordinate
+		for _, s := range p.surfaces {
+			pts = append(pts, shapeVertices(s)...)
+		}
+		return pts
+	default:
+		return nil
+	}
+}
+
+func partDimensionChar(p part) byte {
+	switch p.kind {
+	case partPoints:
+		return '0'
+	case partCurve:
+		return '1'
+	case partSurface:
+		return '2'
+	default:
+		return 'F'
+	}
+}
+
+// classify locates a single coordinate against a whole geometry as
+// Interior, Boundary or Exterior.
+func classify(c Coordinate, g Geometry) byte {
+	switch v := g.(type) {
+	case *Point:
+		if v.Empty {
+			return 'E'
+		}
+		if coordEqual(c, v.Coordinate) {
+			return 'I'
+		}
+		return 'E'
+	case *LineString:
+		return pointOnLineString(c, v.Points)
+	case *Polygon:
+		return pointInPolygon(c, v.Shell, v.Holes)
+	default:
+		return 'E'
+	}
+}
+
+// exteriorDimension answers "does any of part p lie outside otherGeom?".
+func exteriorDimension(p part, otherGeom Geometry) byte {
+	if p.kind == partEmpty {
+		return 'F'
+	}
+	for _, c := range sampleOf(p) {
+		if classify(c, otherGeom) == 'E' {
+			return partDimensionChar(p)
+		}
+	}
+	return 'F'
+}
+
+// relate computes the 9-character DE-9IM matrix between two geometries.
+func relate(a, b Geometry) (string, error) {
+	pa, aok := a.(partedGeometry)
+	pb, bok := b.(partedGeometry)
+	if !aok || !bok {
+		return "", ErrUnsupported
+	}
+
+	aParts := [2]part{pa.interiorPart(), pa.boundaryPart()}
+	bParts := [2]part{pb.interiorPart(), pb.boundaryPart()}
+
+	var m [3][3]byte
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			m[i][j] = intersectionDimension(aParts[i], bParts[j])
+		}
+		m[i][2] = exteriorDimension(aParts[i], b)
+	}
+	for j := 0; j < 2; j++ {
+		m[2][j] = exteriorDimension(bParts[j], a)
+	}
+	m[2][2] = '2'
+
+	var sb strings.Builder
+	for i := 0; i < 3; i++ {
+		sb.Write(m[i][:])
+	}
+	return sb.String(), nil
+}
+
+func matchChar(m, p byte) bool {
+	switch p {
+	case '*':
+		return true
+	case 'T':
+		return m == '0' || m == '1' || m == '2'
+	default:
+		return m == p
+	}
+}
+
+// matchPattern evaluates a computed DE-9IM matrix against a 9-character
+// pattern using 'T', 'F', '0', '1', '2' and '*'.
+func matchPattern(matrix, pattern string) (bool, error) {
+	if len(matrix) != 9 || len(pattern) != 9 {
+		return false, fmt.Errorf("geometry: %w: DE-9IM matrix/pattern must be 9 characters", ErrMalformed)
+	}
+	for i := 0; i < 9; i++ {
+		if !matchChar(matrix[i], pattern[i]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func matchAny(matrix string, patterns []string) (bool, error) {
+	for _, p := range patterns {
+		ok, err := matchPattern(matrix, p)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isTrueDim(c byte) bool { return c == '0' || c == '1' || c == '2' }
+
+func geomDimension(g Geometry) int {
+	switch g.(type) {
+	case *Point:
+		return 0
+	case *LineString:
+		return 1
+	case *Polygon:
+		return 2
+	default:
+		return -1
+	}
+}
+
+func derefGeom(g *Geometry) Geometry {
+	if g == nil {
+		return nil
+	}
+	return *g
+}
+
+// relateMatrix is a small helper shared by every concrete type's
+// STRelate/STRelatePattern/predicate methods below.
+func relateMatrix(self Geometry, g *Geometry) (string, error) {
+	return relate(self, derefGeom(g))
+}
+
+var coversPatterns = []string{"T*****FF*", "*T****FF*", "***T**FF*", "****T*FF*"}
+var coveredByPatterns = []string{"T*F**F***", "*TF**F***", "**FT*F***", "**F*TF***"}
+
+func stEquals(self Geometry, g *Geometry) (bool, error) {
+	m, err := relateMatrix(self, g)
+	if err != nil {
+		return false, err
+	}
+	return matchPattern(m, "T*F**FFF*")
+}
+
+func stDisjoint(self Geometry, g *Geometry) (bool, error) {
+	m, err := relateMatrix(self, g)
+	if err != nil {
+		return false, err
+	}
+	return matchPattern(m, "FF*FF****")
+}
+
+func stIntersects(self Geometry, g *Geometry) (bool, error) {
+	disjoint, err := stDisjoint(self, g)
+	if err != nil {
+		return false, err
+	}
+	return !disjoint, nil
+}
+
+func stTouches(self Geometry, g *Geometry) (bool, error) {
+	m, err := relateMatrix(self, g)
+	if err != nil {
+		return false, err
+	}
+	return m[0] == 'F' && (isTrueDim(m[1]) || isTrueDim(m[3]) || isTrueDim(m[4])), nil
+}
+
+func stCrosses(self Geometry, g *Geometry) (bool, error) {
+	other := derefGeom(g)
+	dA, dB := geomDimension(self), geomDimension(other)
+	if dA == 0 && dB == 0 || dA == 2 && dB == 2 || dA < 0 || dB < 0 {
+		return false, nil
+	}
+	m, err := relateMatrix(self, g)
+	if err != nil {
+		return false, err
+	}
+	if dA == 1 && dB == 2 || dA == 2 && dB == 1 {
+		return matchPattern(m, "1*T***T**")
+	}
+	return matchPattern(m, "0********")
+}
+
+func stWithin(self Geometry, g *Geometry) (bool, error) {
+	m, err := relateMatrix(self, g)
+	if err != nil {
+		return false, err
+	}
+	return matchPattern(m, "T*F**F***")
+}
+
+func stContains(self Geometry, g *Geometry) (bool, error) {
+	m, err := relateMatrix(self, g)
+	if err != nil {
+		return false, err
+	}
+	return matchPattern(m, "T*****FF*")
+}
+
+func stOverlaps(self Geometry, g *Geometry) (bool, error) {
+	other := derefGeom(g)
+	if geomDimension(self) != geomDimension(other) {
+		return false, nil
+	}
+	m, err := relateMatrix(self, g)
+	if err != nil {
+		return false, err
+	}
+	return matchPattern(m, "T*T***T**")
+}
+
+func stCovers(self Geometry, g *Geometry) (bool, error) {
+	m, err := relateMatrix(self, g)
+	if err != nil {
+		return false, err
+	}
+	return matchAny(m, coversPatterns)
+}
+
+func stCoveredBy(self Geometry, g *Geometry) (bool, error) {
+	m, err := relateMatrix(self, g)
+	if err != nil {
+		return false, err
+	}
+	return matchAny(m, coveredByPatterns)
+}
+
+func stRelatePattern(self Geometry, g *Geometry, pattern string) (bool, error) {
+	m, err := relateMatrix(self, g)
+	if err != nil {
+		return false, err
+	}
+	return matchPattern(m, pattern)
+}
+
+func (p *Point) STRelate(g *Geometry) (string, error)      { return relateMatrix(p, g) }
+func (p *Point) STRelatePattern(g *Geometry, pattern string) (bool, error) {
+	return stRelatePattern(p, g, pattern)
+}
+func (p *Point) STEquals(g *Geometry) (bool, error)     { return stEquals(p, g) }
+func (p *Point) STDisjoint(g *Geometry) (bool, error)   { return stDisjoint(p, g) }
+func (p *Point) STIntersects(g *Geometry) (bool, error) { return stIntersects(p, g) }
+func (p *Point) STTouches(g *Geometry) (bool, error)    { return stTouches(p, g) }
+func (p *Point) STCrosses(g *Geometry) (bool, error)    { return stCrosses(p, g) }
+func (p *Point) STWithin(g *Geometry) (bool, error)     { return stWithin(p, g) }
+func (p *Point) STContains(g *Geometry) (bool, error)   { return stContains(p, g) }
+func (p *Point) STOverlaps(g *Geometry) (bool, error)   { return stOverlaps(p, g) }
+func (p *Point) STCovers(g *Geometry) (bool, error)     { return stCovers(p, g) }
+func (p *Point) STCoveredBy(g *Geometry) (bool, error)  { return stCoveredBy(p, g) }
+
+func (l *LineString) STRelate(g *Geometry) (string, error) { return relateMatrix(l, g) }
+func (l *LineString) STRelatePattern(g *Geometry, pattern string) (bool, error) {
+	return stRelatePattern(l, g, pattern)
+}
+func (l *LineString) STEquals(g *Geometry) (bool, error)     { return stEquals(l, g) }
+func (l *LineString) STDisjoint(g *Geometry) (bool, error)   { return stDisjoint(l, g) }
+func (l *LineString) STIntersects(g *Geometry) (bool, error) { return stIntersects(l, g) }
+func (l *LineString) STTouches(g *Geometry) (bool, error)    { return stTouches(l, g) }
+func (l *LineString) STCrosses(g *Geometry) (bool, error)    { return stCrosses(l, g) }
+func (l *LineString) STWithin(g *Geometry) (bool, error)     { return stWithin(l, g) }
+func (l *LineString) STContains(g *Geometry) (bool, error)   { return stContains(l, g) }
+func (l *LineString) STOverlaps(g *Geometry) (bool, error)   { return stOverlaps(l, g) }
+func (l *LineString) STCovers(g *Geometry) (bool, error)     { return stCovers(l, g) }
+func (l *LineString) STCoveredBy(g *Geometry) (bool, error)  { return stCoveredBy(l, g) }
+
+func (pg *Polygon) STRelate(g *Geometry) (string, error) { return relateMatrix(pg, g) }
+func (pg *Polygon) STRelatePattern(g *Geometry, pattern string) (bool, error) {
+	return stRelatePattern(pg, g, pattern)
+}
+func (pg *Polygon) STEquals(g *Geometry) (bool, error)     { return stEquals(pg, g) }
+func (pg *Polygon) STDisjoint(g *Geometry) (bool, error)   { return stDisjoint(pg, g) }
+func (pg *Polygon) STIntersects(g *Geometry) (bool, error) { return stIntersects(pg, g) }
+func (pg *Polygon) STTouches(g *Geometry) (bool, error)    { return stTouches(pg, g) }
+func (pg *Polygon) STCrosses(g *Geometry) (bool, error)    { return stCrosses(pg, g) }
+func (pg *Polygon) STWithin(g *Geometry) (bool, error)     { return stWithin(pg, g) }
+func (pg *Polygon) STContains(g *Geometry) (bool, error)   { return stContains(pg, g) }
+func (pg *Polygon) STOverlaps(g *Geometry) (bool, error)   { return stOverlaps(pg, g) }
+func (pg *Polygon) STCovers(g *Geometry) (bool, error)     { return stCovers(pg, g) }
+func (pg *Polygon) STCoveredBy(g *Geometry) (bool, error)  { return stCoveredBy(pg, g) }
@@ -0,0 +1,149 @@
+package geometry
+
+import "testing"
+
+func mustWKT(t *testing.T, wkt string) Geometry {
+	t.Helper()
+	g, err := FromWKT(wkt)
+	if err != nil {
+		t.Fatalf("FromWKT(%q): %v", wkt, err)
+	}
+	return g
+}
+
+func TestSTRelateMatrix(t *testing.T) {
+	a := mustWKT(t, "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0))")
+	var b Geometry = mustWKT(t, "POINT (2 2)")
+
+	m, err := a.STRelate(&b)
+	if err != nil {
+		t.Fatalf("STRelate: %v", err)
+	}
+	if len(m) != 9 {
+		t.Fatalf("STRelate matrix %q has length %d, want 9", m, len(m))
+	}
+	// The point lies strictly inside the polygon's interior.
+	if m[0] != '0' {
+		t.Errorf("STRelate matrix %q: II entry = %q, want '0' (point interior in polygon interior)", m, m[0])
+	}
+}
+
+func TestSTIntersectsAndDisjoint(t *testing.T) {
+	a := mustWKT(t, "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0))")
+	var inside Geometry = mustWKT(t, "POINT (2 2)")
+	var outside Geometry = mustWKT(t, "POINT (10 10)")
+
+	ok, err := a.STIntersects(&inside)
+	if err != nil {
+		t.Fatalf("STIntersects: %v", err)
+	}
+	if !ok {
+		t.Errorf("expected polygon to intersect a point inside it")
+	}
+
+	ok, err = a.STDisjoint(&outside)
+	if err != nil {
+		t.Fatalf("STDisjoint: %v", err)
+	}
+	if !ok {
+		t.Errorf("expected polygon to be disjoint from a far-away point")
+	}
+}
+
+func TestSTContainsAndWithin(t *testing.T) {
+	a := mustWKT(t, "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0))")
+	var inner Geometry = mustWKT(t, "POINT (1 1)")
+
+	contains, err := a.STContains(&inner)
+	if err != nil {
+		t.Fatalf("STContains: %v", err)
+	}
+	if !contains {
+		t.Errorf("expected polygon to contain an interior point")
+	}
+
+	var outer Geometry = a
+	within, err := inner.(*Point).STWithin(&outer)
+	if err != nil {
+		t.Fatalf("STWithin: %v", err)
+	}
+	if !within {
+		t.Errorf("expected point to be within the polygon containing it")
+	}
+}
+
+func TestSTTouches(t *testing.T) {
+	a := mustWKT(t, "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0))")
+	var onBoundary Geometry = mustWKT(t, "POINT (0 2)")
+
+	touches, err := a.STTouches(&onBoundary)
+	if err != nil {
+		t.Fatalf("STTouches: %v", err)
+	}
+	if !touches {
+		t.Errorf("expected polygon to touch a point on its boundary")
+	}
+
+	intersects, err := a.STIntersects(&onBoundary)
+	if err != nil {
+		t.Fatalf("STIntersects: %v", err)
+	}
+	if !intersects {
+		t.Errorf("a point touching the boundary should also count as intersecting")
+	}
+}
+
+func TestSTRelateEdgeAdjacentPolygons(t *testing.T) {
+	a := mustWKT(t, "POLYGON((0 0,4 0,4 4,0 4,0 0))")
+	var b Geometry = mustWKT(t, "POLYGON((4 0,8 0,8 4,4 4,4 0))")
+
+	m, err := a.STRelate(&b)
+	if err != nil {
+		t.Fatalf("STRelate: %v", err)
+	}
+	want := "FF2F11212"
+	if m != want {
+		t.Errorf("STRelate matrix = %q, want %q (shared edge only -- interiors are disjoint)", m, want)
+	}
+
+	overlaps, err := a.STOverlaps(&b)
+	if err != nil {
+		t.Fatalf("STOverlaps: %v", err)
+	}
+	if overlaps {
+		t.Errorf("two polygons that only share an edge should not overlap")
+	}
+
+	touches, err := a.STTouches(&b)
+	if err != nil {
+		t.Fatalf("STTouches: %v", err)
+	}
+	if !touches {
+		t.Errorf("two polygons sharing an edge should touch")
+	}
+}
+
+func TestSTRelatePattern(t *testing.T) {
+	a := mustWKT(t, "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0))")
+	var inner Geometry = mustWKT(t, "POINT (1 1)")
+
+	m, err := a.STRelate(&inner)
+	if err != nil {
+		t.Fatalf("STRelate: %v", err)
+	}
+	ok, err := a.STRelatePattern(&inner, m)
+	if err != nil {
+		t.Fatalf("STRelatePattern: %v", err)
+	}
+	if !ok {
+		t.Errorf("matrix %q should match itself as a pattern", m)
+	}
+
+	ok, err = a.STRelatePattern(&inner, "FFFFFFFFF")
+	if err != nil {
+		t.Fatalf("STRelatePattern: %v", err)
+	}
+	if ok {
+		t.Errorf("an all-F pattern should never match a non-disjoint pair")
+	}
+}
@@ -0,0 +1,350 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// This file implements true 3D overlap/distance operations (ST3DIntersects,
+// ST3DDistance, ST3DLength, STZMin, STZMax) for Point/LineString/Polygon and
+// their *Z counterparts.
+//
+// Polygons (2D or 3D) are treated via their boundary rings only: distance
+// and intersection against a polygon are computed against its edges, not
+// its filled interior. Testing whether a point lies within a possibly
+// non-planar 3D polygon's interior is a materially harder problem (it
+// requires fitting a best-fit plane and handling out-of-plane points) and
+// is left for later; boundary-based distance is the correct, conservative
+// building block most callers (e.g. "how far is this point from the
+// surface") actually want.
+
+func toXYZ(coords []Coordinate) []CoordinateXYZ {
+	out := make([]CoordinateXYZ, len(coords))
+	for i, c := range coords {
+		out[i] = CoordinateXYZ{X: c.X, Y: c.Y}
+	}
+	return out
+}
+
+func segments3D(pts []CoordinateXYZ) [][2]CoordinateXYZ {
+	if len(pts) < 2 {
+		return nil
+	}
+	segs := make([][2]CoordinateXYZ, len(pts)-1)
+	for i := 0; i < len(pts)-1; i++ {
+		segs[i] = [2]CoordinateXYZ{pts[i], pts[i+1]}
+	}
+	return segs
+}
+
+func ringSegments3D(pts []CoordinateXYZ) [][2]CoordinateXYZ {
+	n := len(pts)
+	if n < 2 {
+		return nil
+	}
+	closed := pts[0] == pts[n-1]
+	count := n - 1
+	if !closed {
+		count = n
+	}
+	segs := make([][2]CoordinateXYZ, count)
+	for i := 0; i < count; i++ {
+		segs[i] = [2]CoordinateXYZ{pts[i], pts[(i+1)%n]}
+	}
+	return segs
+}
+
+// primitive3D is either a set of discrete points (for Point/PointZ) or a
+// set of line segments (for LineString/Polygon and their Z variants, the
+// latter via its boundary rings).
+type primitive3D struct {
+	points   []CoordinateXYZ
+	segments [][2]CoordinateXYZ
+}
+
+func (p primitive3D) empty() bool { return len(p.points) == 0 && len(p.segments) == 0 }
+
+// primitiveOf decomposes a geometry into its 3D primitive, reporting
+// whether the geometry natively carries Z ordinates.
+func primitiveOf(g Geometry) (prim primitive3D, hadZ bool, ok bool) {
+	switch v := g.(type) {
+	case *Point:
+		if v.Empty {
+			return primitive3D{}, false, true
+		}
+		return primitive3D{points: []CoordinateXYZ{{X: v.X, Y: v.Y}}}, false, true
+	case *PointZ:
+		if v.Empty {
+			return primitive3D{}, true, true
+		}
+		return primitive3D{points: []CoordinateXYZ{v.CoordinateXYZ}}, true, true
+	case *LineString:
+		return primitive3D{segments: segments3D(toXYZ(v.Points))}, false, true
+	case *LineStringZ:
+		return primitive3D{segments: segments3D(v.Points)}, true, true
+	case *Polygon:
+		segs := ringSegments3D(toXYZ(v.Shell))
+		for _, h := range v.Holes {
+			segs = append(segs, ringSegments3D(toXYZ(h))...)
+		}
+		return primitive3D{segments: segs}, false, true
+	case *PolygonZ:
+		segs := ringSegments3D(v.Shell)
+		for _, h := range v.Holes {
+			segs = append(segs, ringSegments3D(h)...)
+		}
+		return primitive3D{segments: segs}, true, true
+	default:
+		return primitive3D{}, false, false
+	}
+}
+
+func sub3(a, b CoordinateXYZ) CoordinateXYZ {
+	return CoordinateXYZ{X: a.X - b.X, Y: a.Y - b.Y, Z: a.Z - b.Z}
+}
+
+func add3(a, b CoordinateXYZ) CoordinateXYZ {
+	return CoordinateXYZ{X: a.X + b.X, Y: a.Y + b.Y, Z: a.Z + b.Z}
+}
+
+func scale3(a CoordinateXYZ, s float64) CoordinateXYZ {
+	return CoordinateXYZ{X: a.X * s, Y: a.Y * s, Z: a.Z * s}
+}
+
+func dot3(a, b CoordinateXYZ) float64 { return a.X*b.X + a.Y*b.Y + a.Z*b.Z }
+
+func distance3D(a, b CoordinateXYZ) float64 {
+	d := sub3(a, b)
+	return math.Sqrt(dot3(d, d))
+}
+
+func pointSegmentDistance3D(p, a, b CoordinateXYZ) float64 {
+	ab := sub3(b, a)
+	denom := dot3(ab, ab)
+	t := 0.0
+	if denom > 0 {
+		t = clamp01(dot3(sub3(p, a), ab) / denom)
+	}
+	closest := add3(a, scale3(ab, t))
+	return distance3D(p, closest)
+}
+
+// segmentSegmentDistance3D returns the minimum distance between segments
+// p1-q1 and p2-q2 via their closest pair of points, clamping each
+// parameter to its segment.
+func segmentSegmentDistance3D(p1, q1, p2, q2 CoordinateXYZ) float64 {
+	d1 := sub3(q1, p1)
+	d2 := sub3(q2, p2)
+	r := sub3(p1, p2)
+	a := dot3(d1, d1)
+	e := dot3(d2, d2)
+	f := dot3(d2, r)
+
+	const tiny = 1e-12
+	var s, t float64
+	switch {
+	case a <= tiny && e <= tiny:
+		s, t = 0, 0
+	case a <= tiny:
+		s = 0
+		t = clamp01(f / e)
+	case e <= tiny:
+		t = 0
+		s = clamp01(-dot3(d1, r) / a)
+	default:
+		c := dot3(d1, r)
+		b := dot3(d1, d2)
+		denom := a*e - b*b
+		if denom != 0 {
+			s = clamp01((b*f - c*e) / denom)
+		} else {
+			s = 0
+		}
+		t = (b*s + f) / e
+		if t < 0 {
+			t, s = 0, clamp01(-c/a)
+		} else if t > 1 {
+			t, s = 1, clamp01((b-c)/a)
+		}
+	}
+	c1 := add3(p1, scale3(d1, s))
+	c2 := add3(p2, scale3(d2, t))
+	return distance3D(c1, c2)
+}
+
+func primitiveDistance3D(a, b primitive3D) float64 {
+	best := math.Inf(1)
+	consider := func(d float64) {
+		if d < best {
+			best = d
+		}
+	}
+	for _, pa := range a.points {
+		for _, pb := range b.points {
+			consider(distance3D(pa, pb))
+		}
+		for _, sb := range b.segments {
+			consider(pointSegmentDistance3D(pa, sb[0], sb[1]))
+		}
+	}
+	for _, sa := range a.segments {
+		for _, pb := range b.points {
+			consider(pointSegmentDistance3D(pb, sa[0], sa[1]))
+		}
+		for _, sb := range b.segments {
+			consider(segmentSegmentDistance3D(sa[0], sa[1], sb[0], sb[1]))
+		}
+	}
+	return best
+}
+
+var errEmptyGeometry = fmt.Errorf("geometry: operation requires a non-empty geometry")
+
+func checkZPolicy(hadZA, hadZB bool) error {
+	if DefaultZPolicy == ZStrict && (!hadZA || !hadZB) {
+		return fmt.Errorf("geometry: %w: ST3D operation requires both geometries to carry Z ordinates under ZStrict policy", ErrUnsupported)
+	}
+	return nil
+}
+
+func st3DDistance(self Geometry, g *Geometry) (float64, error) {
+	other := derefGeom(g)
+	pa, hadZA, okA := primitiveOf(self)
+	pb, hadZB, okB := primitiveOf(other)
+	if !okA || !okB {
+		return 0, ErrUnsupported
+	}
+	if err := checkZPolicy(hadZA, hadZB); err != nil {
+		return 0, err
+	}
+	if pa.empty() || pb.empty() {
+		return 0, errEmptyGeometry
+	}
+	return primitiveDistance3D(pa, pb), nil
+}
+
+func st3DIntersects(self Geometry, g *Geometry) (bool, error) {
+	d, err := st3DDistance(self, g)
+	if err != nil {
+		return false, err
+	}
+	return d <= epsilon, nil
+}
+
+func st3DLength(self Geometry) (float64, error) {
+	var pts []CoordinateXYZ
+	switch v := self.(type) {
+	case *LineString:
+		if DefaultZPolicy == ZStrict {
+			return 0, fmt.Errorf("geometry: %w: ST3DLength requires Z ordinates under ZStrict policy", ErrUnsupported)
+		}
+		pts = toXYZ(v.Points)
+	case *LineStringZ:
+		pts = v.Points
+	default:
+		return 0, ErrUnsupported
+	}
+	total := 0.0
+	for i := 0; i+1 < len(pts); i++ {
+		total += distance3D(pts[i], pts[i+1])
+	}
+	return total, nil
+}
+
+func allCoordinatesZ(self Geometry) ([]CoordinateXYZ, bool, bool) {
+	switch v := self.(type) {
+	case *Point:
+		if v.Empty {
+			return nil, false, true
+		}
+		return []CoordinateXYZ{{X: v.X, Y: v.Y}}, false, true
+	case *PointZ:
+		if v.Empty {
+			return nil, true, true
+		}
+		return []CoordinateXYZ{v.CoordinateXYZ}, true, true
+	case *LineString:
+		return toXYZ(v.Points), false, true
+	case *LineStringZ:
+		return v.Points, true, true
+	case *Polygon:
+		pts := toXYZ(v.Shell)
+		for _, h := range v.Holes {
+			pts = append(pts, toXYZ(h)...)
+		}
+		return pts, false, true
+	case *PolygonZ:
+		pts := append([]CoordinateXYZ{}, v.Shell...)
+		for _, h := range v.Holes {
+			pts = append(pts, h...)
+		}
+		return pts, true, true
+	default:
+		return nil, false, false
+	}
+}
+
+func stZRange(self Geometry) (float64, float64, error) {
+	pts, hadZ, ok := allCoordinatesZ(self)
+	if !ok {
+		return 0, 0, ErrUnsupported
+	}
+	if DefaultZPolicy == ZStrict && !hadZ {
+		return 0, 0, fmt.Errorf("geometry: %w: STZMin/STZMax require Z ordinates under ZStrict policy", ErrUnsupported)
+	}
+	if len(pts) == 0 {
+		return 0, 0, errEmptyGeometry
+	}
+	min, max := pts[0].Z, pts[0].Z
+	for _, p := range pts[1:] {
+		if p.Z < min {
+			min = p.Z
+		}
+		if p.Z > max {
+			max = p.Z
+		}
+	}
+	return min, max, nil
+}
+
+func stZMin(self Geometry) (float64, error) {
+	min, _, err := stZRange(self)
+	return min, err
+}
+
+func stZMax(self Geometry) (float64, error) {
+	_, max, err := stZRange(self)
+	return max, err
+}
+
+func (p *Point) ST3DIntersects(g *Geometry) (bool, error)   { return st3DIntersects(p, g) }
+func (p *Point) ST3DDistance(g *Geometry) (float64, error)  { return st3DDistance(p, g) }
+func (p *Point) STZMin() (float64, error)                   { return stZMin(p) }
+func (p *Point) STZMax() (float64, error)                   { return stZMax(p) }
+
+func (l *LineString) ST3DIntersects(g *Geometry) (bool, error)  { return st3DIntersects(l, g) }
+func (l *LineString) ST3DDistance(g *Geometry) (float64, error) { return st3DDistance(l, g) }
+func (l *LineString) ST3DLength() (float64, error)              { return st3DLength(l) }
+func (l *LineString) STZMin() (float64, error)                  { return stZMin(l) }
+func (l *LineString) STZMax() (float64, error)                  { return stZMax(l) }
+
+func (pg *Polygon) ST3DIntersects(g *Geometry) (bool, error)  { return st3DIntersects(pg, g) }
+func (pg *Polygon) ST3DDistance(g *Geometry) (float64, error) { return st3DDistance(pg, g) }
+func (pg *Polygon) STZMin() (float64, error)                  { return stZMin(pg) }
+func (pg *Polygon) STZMax() (float64, error)                  { return stZMax(pg) }
+
+func (p *PointZ) ST3DIntersects(g *Geometry) (bool, error)  { return st3DIntersects(p, g) }
+func (p *PointZ) ST3DDistance(g *Geometry) (float64, error) { return st3DDistance(p, g) }
+func (p *PointZ) STZMin() (float64, error)                  { return stZMin(p) }
+func (p *PointZ) STZMax() (float64, error)                  { return stZMax(p) }
+
+func (l *LineStringZ) ST3DIntersects(g *Geometry) (bool, error)  { return st3DIntersects(l, g) }
+func (l *LineStringZ) ST3DDistance(g *Geometry) (float64, error) { return st3DDistance(l, g) }
+func (l *LineStringZ) ST3DLength() (float64, error)              { return st3DLength(l) }
+func (l *LineStringZ) STZMin() (float64, error)                  { return stZMin(l) }
+func (l *LineStringZ) STZMax() (float64, error)                  { return stZMax(l) }
+
+func (pg *PolygonZ) ST3DIntersects(g *Geometry) (bool, error)  { return st3DIntersects(pg, g) }
+func (pg *PolygonZ) ST3DDistance(g *Geometry) (float64, error) { return st3DDistance(pg, g) }
+func (pg *PolygonZ) STZMin() (float64, error)                  { return stZMin(pg) }
+func (pg *PolygonZ) STZMax() (float64, error)                  { return stZMax(pg) }
@@ -0,0 +1,119 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestST3DDistancePoints(t *testing.T) {
+	a := NewPointZ(0, 0, 0)
+	var b Geometry = NewPointZ(3, 4, 0)
+	var av Geometry = a
+
+	dist, err := av.ST3DDistance(&b)
+	if err != nil {
+		t.Fatalf("ST3DDistance: %v", err)
+	}
+	if dist != 5 {
+		t.Errorf("ST3DDistance = %v, want 5", dist)
+	}
+}
+
+func TestST3DDistanceSeparatesFromPlanarDistance(t *testing.T) {
+	a := NewPointZ(0, 0, 0)
+	var b Geometry = NewPointZ(0, 0, 10)
+	var av Geometry = a
+
+	dist, err := av.ST3DDistance(&b)
+	if err != nil {
+		t.Fatalf("ST3DDistance: %v", err)
+	}
+	if dist != 10 {
+		t.Errorf("ST3DDistance along Z = %v, want 10 (would be 0 under a 2D-only distance)", dist)
+	}
+}
+
+func TestST3DIntersects(t *testing.T) {
+	a := NewLineStringZ([]CoordinateXYZ{{X: 0, Y: 0, Z: 0}, {X: 2, Y: 2, Z: 2}})
+	var touching Geometry = NewPointZ(1, 1, 1)
+	var away Geometry = NewPointZ(10, 10, 10)
+	var av Geometry = a
+
+	ok, err := av.ST3DIntersects(&touching)
+	if err != nil {
+		t.Fatalf("ST3DIntersects: %v", err)
+	}
+	if !ok {
+		t.Errorf("expected line to 3D-intersect a point on it")
+	}
+
+	ok, err = av.ST3DIntersects(&away)
+	if err != nil {
+		t.Fatalf("ST3DIntersects: %v", err)
+	}
+	if ok {
+		t.Errorf("expected line not to 3D-intersect a far-away point")
+	}
+}
+
+func TestSTZMinMax(t *testing.T) {
+	l := NewLineStringZ([]CoordinateXYZ{{X: 0, Y: 0, Z: -5}, {X: 1, Y: 1, Z: 8}, {X: 2, Y: 2, Z: 1}})
+
+	zmin, err := l.STZMin()
+	if err != nil {
+		t.Fatalf("STZMin: %v", err)
+	}
+	if zmin != -5 {
+		t.Errorf("STZMin = %v, want -5", zmin)
+	}
+
+	zmax, err := l.STZMax()
+	if err != nil {
+		t.Fatalf("STZMax: %v", err)
+	}
+	if zmax != 8 {
+		t.Errorf("STZMax = %v, want 8", zmax)
+	}
+}
+
+func TestST3DLength(t *testing.T) {
+	l := NewLineStringZ([]CoordinateXYZ{{X: 0, Y: 0, Z: 0}, {X: 3, Y: 4, Z: 0}, {X: 3, Y: 4, Z: 12}})
+	length, err := l.ST3DLength()
+	if err != nil {
+		t.Fatalf("ST3DLength: %v", err)
+	}
+	want := 5.0 + 12.0
+	if math.Abs(length-want) > 1e-9 {
+		t.Errorf("ST3DLength = %v, want %v", length, want)
+	}
+}
+
+func TestST3DDistanceZPolicyAssumesZero(t *testing.T) {
+	orig := DefaultZPolicy
+	defer func() { DefaultZPolicy = orig }()
+	DefaultZPolicy = ZAssumeZero
+
+	var a Geometry = NewPoint(0, 0)
+	var b Geometry = NewPointZ(0, 0, 5)
+
+	dist, err := a.ST3DDistance(&b)
+	if err != nil {
+		t.Fatalf("ST3DDistance under ZAssumeZero: %v", err)
+	}
+	if dist != 5 {
+		t.Errorf("ST3DDistance = %v, want 5 (2D point's Z treated as 0)", dist)
+	}
+}
+
+func TestST3DDistanceZPolicyStrictRejectsMissingZ(t *testing.T) {
+	orig := DefaultZPolicy
+	defer func() { DefaultZPolicy = orig }()
+	DefaultZPolicy = ZStrict
+
+	var a Geometry = NewPoint(0, 0)
+	var b Geometry = NewPointZ(0, 0, 5)
+
+	if _, err := a.ST3DDistance(&b); err == nil {
+		t.Errorf("expected error under ZStrict when one operand has no Z ordinate")
+	}
+}
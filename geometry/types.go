@@ -0,0 +1,94 @@
+package geometry
+
+// Coordinate is a single XY position. Geometry types are built out of
+// slices of Coordinate; Z support is tracked separately (see CoordinateXYZ).
+type Coordinate struct {
+	X, Y float64
+}
+
+// Point is a single Coordinate, optionally tagged with an SRID carried over
+// from EWKT/EWKB. SRID is 0 when the geometry did not come from an
+// extended representation.
+type Point struct {
+	unsupported
+	Coordinate
+	Empty bool
+	SRID  int
+}
+
+func NewPoint(x, y float64) *Point {
+	return &Point{Coordinate: Coordinate{X: x, Y: y}}
+}
+
+func NewEmptyPoint() *Point {
+	return &Point{Empty: true}
+}
+
+// LineString is an ordered sequence of two or more Coordinates.
+type LineString struct {
+	unsupported
+	Points []Coordinate
+	SRID   int
+}
+
+func NewLineString(points []Coordinate) *LineString {
+	return &LineString{Points: points}
+}
+
+// Polygon is a shell ring plus zero or more interior (hole) rings. Rings
+// are not required to be explicitly closed by the caller; WKT/WKB codecs
+// preserve whatever ring was given.
+type Polygon struct {
+	unsupported
+	Shell []Coordinate
+	Holes [][]Coordinate
+	SRID  int
+}
+
+func NewPolygon(shell []Coordinate, holes [][]Coordinate) *Polygon {
+	return &Polygon{Shell: shell, Holes: holes}
+}
+
+// MultiPoint is a collection of Points.
+type MultiPoint struct {
+	unsupported
+	Points []*Point
+	SRID   int
+}
+
+func NewMultiPoint(points []*Point) *MultiPoint {
+	return &MultiPoint{Points: points}
+}
+
+// MultiLineString is a collection of LineStrings.
+type MultiLineString struct {
+	unsupported
+	Lines []*LineString
+	SRID  int
+}
+
+func NewMultiLineString(lines []*LineString) *MultiLineString {
+	return &MultiLineString{Lines: lines}
+}
+
+// MultiPolygon is a collection of Polygons.
+type MultiPolygon struct {
+	unsupported
+	Polygons []*Polygon
+	SRID     int
+}
+
+func NewMultiPolygon(polygons []*Polygon) *MultiPolygon {
+	return &MultiPolygon{Polygons: polygons}
+}
+
+// GeometryCollection is a heterogeneous collection of Geometry values.
+type GeometryCollection struct {
+	unsupported
+	Geometries []Geometry
+	SRID       int
+}
+
+func NewGeometryCollection(geoms []Geometry) *GeometryCollection {
+	return &GeometryCollection{Geometries: geoms}
+}
@@ -0,0 +1,64 @@
+package geometry
+
+// CoordinateXYZ is a single XYZ position, used by the *Z geometry variants
+// (PointZ, LineStringZ, PolygonZ).
+type CoordinateXYZ struct {
+	X, Y, Z float64
+}
+
+// ZPolicy controls how ST3D* and STZ* operations treat geometries that
+// have no Z ordinate of their own (plain Point/LineString/Polygon).
+type ZPolicy int
+
+const (
+	// ZAssumeZero treats a missing Z ordinate as 0. This is the default,
+	// matching how most producers of 2D WKT/WKB behave.
+	ZAssumeZero ZPolicy = iota
+	// ZStrict rejects 3D operations when either operand lacks a real Z
+	// ordinate.
+	ZStrict
+)
+
+// DefaultZPolicy governs every ST3D*/STZ* call in the package. It is a
+// package-level switch rather than a per-call option because callers
+// overwhelmingly want one consistent behavior for their whole dataset.
+var DefaultZPolicy = ZAssumeZero
+
+// PointZ is a single CoordinateXYZ, the 3D counterpart of Point.
+type PointZ struct {
+	unsupported
+	CoordinateXYZ
+	Empty bool
+	SRID  int
+}
+
+func NewPointZ(x, y, z float64) *PointZ {
+	return &PointZ{CoordinateXYZ: CoordinateXYZ{X: x, Y: y, Z: z}}
+}
+
+func NewEmptyPointZ() *PointZ {
+	return &PointZ{Empty: true}
+}
+
+// LineStringZ is the 3D counterpart of LineString.
+type LineStringZ struct {
+	unsupported
+	Points []CoordinateXYZ
+	SRID   int
+}
+
+func NewLineStringZ(points []CoordinateXYZ) *LineStringZ {
+	return &LineStringZ{Points: points}
+}
+
+// PolygonZ is the 3D counterpart of Polygon.
+type PolygonZ struct {
+	unsupported
+	Shell []CoordinateXYZ
+	Holes [][]CoordinateXYZ
+	SRID  int
+}
+
+func NewPolygonZ(shell []CoordinateXYZ, holes [][]CoordinateXYZ) *PolygonZ {
+	return &PolygonZ{Shell: shell, Holes: holes}
+}
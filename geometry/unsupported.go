@@ -0,0 +1,60 @@
+package geometry
+
+// unsupported implements every method of Geometry as a stub: methods with
+// an error result return ErrUnsupported, the transform methods
+// (STTranslate, STScale, STRotate, STAffine, STSnapToGrid) return nil, and
+// STBuffer does nothing. Concrete types embed it so that adding a new
+// struct to the package only requires implementing the operations that
+// struct actually supports; everything else compiles and reports itself as
+// unsupported in one of those ways. As support lands for a given operation on a given type, the
+// type gains its own method which shadows the embedded stub.
+type unsupported struct{}
+
+func (unsupported) STEquals(g *Geometry) (bool, error)      { return false, ErrUnsupported }
+func (unsupported) STDisjoint(g *Geometry) (bool, error)    { return false, ErrUnsupported }
+func (unsupported) STIntersects(g *Geometry) (bool, error)  { return false, ErrUnsupported }
+func (unsupported) STTouches(g *Geometry) (bool, error)     { return false, ErrUnsupported }
+func (unsupported) STCrosses(g *Geometry) (bool, error)     { return false, ErrUnsupported }
+func (unsupported) STWithin(g *Geometry) (bool, error)      { return false, ErrUnsupported }
+func (unsupported) STContains(g *Geometry) (bool, error)    { return false, ErrUnsupported }
+func (unsupported) STOverlaps(g *Geometry) (bool, error)    { return false, ErrUnsupported }
+func (unsupported) STRelate(g *Geometry) (string, error)    { return "", ErrUnsupported }
+func (unsupported) STRelatePattern(g *Geometry, pattern string) (bool, error) {
+	return false, ErrUnsupported
+}
+func (unsupported) STCovers(g *Geometry) (bool, error)   { return false, ErrUnsupported }
+func (unsupported) STCoveredBy(g *Geometry) (bool, error) { return false, ErrUnsupported }
+func (unsupported) STDistance(g *Geometry) (float64, error) { return 0, ErrUnsupported }
+func (unsupported) STDWithin(g *Geometry, distance float64) (bool, error) {
+	return false, ErrUnsupported
+}
+func (unsupported) STArea() (float64, error)                { return 0, ErrUnsupported }
+func (unsupported) STLength() (float64, error)               { return 0, ErrUnsupported }
+func (unsupported) STPerimeter() (float64, error)            { return 0, ErrUnsupported }
+func (unsupported) STBuffer(radius_to_buffer float64, quad_segs int) {}
+func (unsupported) STConvexHull() (Geometry, error)                    { return nil, ErrUnsupported }
+func (unsupported) STIntersection(g *Geometry) (Geometry, error)       { return nil, ErrUnsupported }
+func (unsupported) STUnion(g *Geometry) (Geometry, error)              { return nil, ErrUnsupported }
+func (unsupported) STDifference(g *Geometry) (Geometry, error)         { return nil, ErrUnsupported }
+func (unsupported) STCentroid(g *Geometry) (Geometry, error)           { return nil, ErrUnsupported }
+func (unsupported) STPointOnSurface() (Geometry, error)                { return nil, ErrUnsupported }
+func (unsupported) STExteriorRing() (Geometry, error)                  { return nil, ErrUnsupported }
+func (unsupported) STInteriorRingN(interior_ring int) (Geometry, error) {
+	return nil, ErrUnsupported
+}
+func (unsupported) STNumInteriorRings() (int, error)            { return 0, ErrUnsupported }
+func (unsupported) STGeometryN(n_element_geo int) (Geometry, error) { return nil, ErrUnsupported }
+func (unsupported) STNumGeometries() (int, error)                { return 0, ErrUnsupported }
+func (unsupported) STSimplify(tolerence int) (Geometry, error)   { return nil, ErrUnsupported }
+func (unsupported) AsText() (string, error)                      { return "", ErrUnsupported }
+func (unsupported) AsBinary() ([]byte, error)                    { return nil, ErrUnsupported }
+func (unsupported) ST3DIntersects(g *Geometry) (bool, error)     { return false, ErrUnsupported }
+func (unsupported) ST3DDistance(g *Geometry) (float64, error)    { return 0, ErrUnsupported }
+func (unsupported) ST3DLength() (float64, error)                 { return 0, ErrUnsupported }
+func (unsupported) STZMin() (float64, error)                     { return 0, ErrUnsupported }
+func (unsupported) STZMax() (float64, error)                     { return 0, ErrUnsupported }
+func (unsupported) STTranslate(dx, dy float64) Geometry          { return nil }
+func (unsupported) STScale(sx, sy float64) Geometry              { return nil }
+func (unsupported) STRotate(radians float64, origin *Point) Geometry { return nil }
+func (unsupported) STAffine(a, b, d, e, xoff, yoff float64) Geometry { return nil }
+func (unsupported) STSnapToGrid(size float64) Geometry           { return nil }
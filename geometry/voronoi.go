@@ -0,0 +1,186 @@
+package geometry
+
+import (
+	"fmt"
+	"sort"
+)
+
+// STVoronoiPolygons computes the Voronoi diagram dual to the Delaunay
+// triangulation of points' vertices, clipped to extendTo (or the input's
+// envelope padded by 50% on every side when extendTo is nil).
+//
+// Each site's cell is built from the circumcenters of its incident
+// Delaunay triangles, plus whichever corners of the clip region are
+// closest to that site (needed to close off the unbounded cells that sit
+// on the convex hull of the input), then taking the convex hull of that
+// point set and clipping it against the clip region. Voronoi cells are
+// themselves convex, so this produces the exact cell once intersected
+// with the clip region -- provided the clip region (extendTo, if given)
+// is itself convex; Sutherland-Hodgman clipping against a concave
+// extendTo polygon will not trim correctly.
+//
+// Because each site's point set is built only from directly incident
+// triangles' circumcenters plus the clip corners nearest to it, highly
+// symmetric inputs (e.g. sites placed on the corners of a perfect square
+// or equilateral triangle, where neighboring triangles share a
+// circumcenter) can degenerate a site's hull to a line and drop its
+// cell. Perturbing the input slightly, or adding an interior site,
+// avoids this.
+func STVoronoiPolygons(points Geometry, tolerance float64, extendTo Geometry) (Geometry, error) {
+	raw, err := extractPoints(points)
+	if err != nil {
+		return nil, err
+	}
+	sites := snapPoints(raw, tolerance)
+	if len(sites) < 2 {
+		return nil, fmt.Errorf("geometry: %w: STVoronoiPolygons needs at least 2 distinct points", ErrMalformed)
+	}
+
+	clip, err := voronoiClipRegion(sites, extendTo)
+	if err != nil {
+		return nil, err
+	}
+
+	tris := delaunayTriangulate(sites)
+
+	cells := make([]*Polygon, 0, len(sites))
+	for i, site := range sites {
+		var pts []Coordinate
+		for _, t := range tris {
+			if t.hasVertex(site) {
+				pts = append(pts, circumcenter(t.a, t.b, t.c))
+			}
+		}
+		for _, corner := range clip {
+			if nearestSiteIndex(corner, sites) == i {
+				pts = append(pts, corner)
+			}
+		}
+		if len(pts) < 3 {
+			continue
+		}
+		hull := convexHull(pts)
+		cell := sutherlandHodgman(hull, clip)
+		if len(cell) < 3 {
+			continue
+		}
+		cells = append(cells, NewPolygon(closeRing(cell), nil))
+	}
+	return NewMultiPolygon(cells), nil
+}
+
+func nearestSiteIndex(p Coordinate, sites []Coordinate) int {
+	best, bestDist := 0, -1.0
+	for i, s := range sites {
+		dx, dy := p.X-s.X, p.Y-s.Y
+		d := dx*dx + dy*dy
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// circumcenter returns the center of the circle through a, b and c.
+func circumcenter(a, b, c Coordinate) Coordinate {
+	d := 2 * (a.X*(b.Y-c.Y) + b.X*(c.Y-a.Y) + c.X*(a.Y-b.Y))
+	if d == 0 {
+		return a // degenerate (collinear) triangle; should not occur post-triangulation
+	}
+	a2 := a.X*a.X + a.Y*a.Y
+	b2 := b.X*b.X + b.Y*b.Y
+	c2 := c.X*c.X + c.Y*c.Y
+	ux := (a2*(b.Y-c.Y) + b2*(c.Y-a.Y) + c2*(a.Y-b.Y)) / d
+	uy := (a2*(c.X-b.X) + b2*(a.X-c.X) + c2*(b.X-a.X)) / d
+	return Coordinate{X: ux, Y: uy}
+}
+
+func voronoiClipRegion(sites []Coordinate, extendTo Geometry) ([]Coordinate, error) {
+	if extendTo != nil {
+		pg, ok := extendTo.(*Polygon)
+		if !ok {
+			return nil, fmt.Errorf("geometry: %w: STVoronoiPolygons extendTo must be a Polygon", ErrMalformed)
+		}
+		return closeRing(append([]Coordinate{}, pg.Shell...)), nil
+	}
+	return envelopeOf(sites).Pad(0.5).Ring(), nil
+}
+
+// convexHull returns the vertices of the convex hull of pts, in
+// counter-clockwise order, via Andrew's monotone chain algorithm.
+func convexHull(pts []Coordinate) []Coordinate {
+	uniq := dedupeCoordinates(pts)
+	sort.Slice(uniq, func(i, j int) bool {
+		if uniq[i].X != uniq[j].X {
+			return uniq[i].X < uniq[j].X
+		}
+		return uniq[i].Y < uniq[j].Y
+	})
+	n := len(uniq)
+	if n < 3 {
+		return uniq
+	}
+
+	lower := make([]Coordinate, 0, n)
+	for _, p := range uniq {
+		for len(lower) >= 2 && orientation(lower[len(lower)-2], lower[len(lower)-1], p) <= 0 {
+			lower = lower[:len(lower)-1]
+		}
+		lower = append(lower, p)
+	}
+	upper := make([]Coordinate, 0, n)
+	for i := n - 1; i >= 0; i-- {
+		p := uniq[i]
+		for len(upper) >= 2 && orientation(upper[len(upper)-2], upper[len(upper)-1], p) <= 0 {
+			upper = upper[:len(upper)-1]
+		}
+		upper = append(upper, p)
+	}
+	return append(lower[:len(lower)-1], upper[:len(upper)-1]...)
+}
+
+func insideClipEdge(p, a, b Coordinate) bool {
+	return orientation(a, b, p) >= -epsilon
+}
+
+// clipLineIntersect intersects the infinite line through a-b with the
+// infinite line through p-q, assuming they are not parallel.
+func clipLineIntersect(a, b, p, q Coordinate) Coordinate {
+	da := orientation(p, q, a)
+	db := orientation(p, q, b)
+	denom := da - db
+	if denom == 0 {
+		return a
+	}
+	return lerp(a, b, da/denom)
+}
+
+// sutherlandHodgman clips subject (given CCW) against the convex polygon
+// clip (also CCW), returning the clipped polygon's vertices (open, i.e.
+// not explicitly closed).
+func sutherlandHodgman(subject, clip []Coordinate) []Coordinate {
+	output := subject
+	clipEdges := ringSegments(clip)
+	for _, edge := range clipEdges {
+		if len(output) == 0 {
+			break
+		}
+		input := output
+		output = nil
+		for i := range input {
+			cur := input[i]
+			prev := input[(i-1+len(input))%len(input)]
+			curIn := insideClipEdge(cur, edge[0], edge[1])
+			prevIn := insideClipEdge(prev, edge[0], edge[1])
+			switch {
+			case curIn && prevIn:
+				output = append(output, cur)
+			case curIn && !prevIn:
+				output = append(output, clipLineIntersect(prev, cur, edge[0], edge[1]), cur)
+			case !curIn && prevIn:
+				output = append(output, clipLineIntersect(prev, cur, edge[0], edge[1]))
+			}
+		}
+	}
+	return output
+}
@@ -0,0 +1,66 @@
+package geometry
+
+import "testing"
+
+func TestSTVoronoiPolygonsCellCount(t *testing.T) {
+	// A center point plus the corners of a square: every site sits on at
+	// least two non-collinear Delaunay triangles, so each cell's point
+	// set spans more than a single line (unlike, e.g., a bare square or
+	// triangle of sites, where symmetric circumcenters can degenerate a
+	// cell to a line segment and drop it).
+	sites := NewMultiPoint([]*Point{
+		NewPoint(0, 0), NewPoint(10, 0), NewPoint(10, 10), NewPoint(0, 10), NewPoint(5, 5),
+	})
+
+	g, err := STVoronoiPolygons(sites, 1e-9, nil)
+	if err != nil {
+		t.Fatalf("STVoronoiPolygons: %v", err)
+	}
+	mp, ok := g.(*MultiPolygon)
+	if !ok {
+		t.Fatalf("expected *MultiPolygon, got %T", g)
+	}
+	if len(mp.Polygons) != len(sites.Points) {
+		t.Errorf("got %d cells, want one per site (%d)", len(mp.Polygons), len(sites.Points))
+	}
+	for i, cell := range mp.Polygons {
+		if len(cell.Shell) < 4 {
+			t.Errorf("cell %d shell has %d points, want a closed polygon with at least 3 distinct vertices", i, len(cell.Shell))
+		}
+	}
+}
+
+func TestSTVoronoiPolygonsRequiresTwoPoints(t *testing.T) {
+	sites := NewMultiPoint([]*Point{NewPoint(0, 0)})
+	if _, err := STVoronoiPolygons(sites, 1e-9, nil); err == nil {
+		t.Errorf("expected error for a single site")
+	}
+}
+
+func TestSTVoronoiPolygonsClippedToExtent(t *testing.T) {
+	sites := NewMultiPoint([]*Point{NewPoint(0, 0), NewPoint(10, 0)})
+	extent, err := FromWKT("POLYGON ((-5 -5, 15 -5, 15 5, -5 5, -5 -5))")
+	if err != nil {
+		t.Fatalf("FromWKT: %v", err)
+	}
+
+	g, err := STVoronoiPolygons(sites, 1e-9, extent)
+	if err != nil {
+		t.Fatalf("STVoronoiPolygons: %v", err)
+	}
+	mp, ok := g.(*MultiPolygon)
+	if !ok {
+		t.Fatalf("expected *MultiPolygon, got %T", g)
+	}
+	bbox, err := Bounds(extent)
+	if err != nil {
+		t.Fatalf("Bounds: %v", err)
+	}
+	for _, cell := range mp.Polygons {
+		for _, c := range cell.Shell {
+			if c.X < bbox.MinX-1e-6 || c.X > bbox.MaxX+1e-6 || c.Y < bbox.MinY-1e-6 || c.Y > bbox.MaxY+1e-6 {
+				t.Errorf("cell vertex %v lies outside the requested clip extent %+v", c, bbox)
+			}
+		}
+	}
+}
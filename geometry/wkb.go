@@ -0,0 +1,412 @@
+package geometry
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Well-Known Binary type codes, per the OGC Simple Features spec.
+const (
+	wkbPoint              = 1
+	wkbLineString         = 2
+	wkbPolygon            = 3
+	wkbMultiPoint         = 4
+	wkbMultiLineString    = 5
+	wkbMultiPolygon       = 6
+	wkbGeometryCollection = 7
+)
+
+// EWKB (PostGIS extended WKB) sets high bits of the type word to flag
+// optional Z/M ordinates and a trailing SRID; the SRID and Z flags are
+// handled here, the M flag is not.
+const ewkbSRIDFlag = 0x20000000
+
+// ewkbZFlag marks the presence of a Z ordinate on every coordinate of the
+// geometry, again per the PostGIS EWKB convention.
+const ewkbZFlag = 0x80000000
+
+// FromWKB parses a Well-Known Binary (or EWKB) byte slice into the
+// matching concrete Geometry implementation.
+func FromWKB(b []byte) (Geometry, error) {
+	r := &wkbReader{buf: b}
+	geom, err := r.readGeometry()
+	if err != nil {
+		return nil, err
+	}
+	if r.pos != len(r.buf) {
+		return nil, fmt.Errorf("geometry: %w: %d trailing byte(s) after WKB geometry", ErrMalformed, len(r.buf)-r.pos)
+	}
+	return geom, nil
+}
+
+type wkbReader struct {
+	buf   []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) need(n int) error {
+	if r.pos+n > len(r.buf) {
+		return fmt.Errorf("geometry: %w: unexpected end of WKB input", ErrMalformed)
+	}
+	return nil
+}
+
+func (r *wkbReader) readByte() (byte, error) {
+	if err := r.need(1); err != nil {
+		return 0, err
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	if err := r.need(4); err != nil {
+		return 0, err
+	}
+	v := r.order.Uint32(r.buf[r.pos : r.pos+4])
+	r.pos += 4
+	return v, nil
+}
+
+func (r *wkbReader) readFloat64() (float64, error) {
+	if err := r.need(8); err != nil {
+		return 0, err
+	}
+	v := math.Float64frombits(r.order.Uint64(r.buf[r.pos : r.pos+8]))
+	r.pos += 8
+	return v, nil
+}
+
+func (r *wkbReader) readCoordinate() (Coordinate, error) {
+	x, err := r.readFloat64()
+	if err != nil {
+		return Coordinate{}, err
+	}
+	y, err := r.readFloat64()
+	if err != nil {
+		return Coordinate{}, err
+	}
+	return Coordinate{X: x, Y: y}, nil
+}
+
+func (r *wkbReader) readCoordinateSequence() ([]Coordinate, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	coords := make([]Coordinate, n)
+	for i := range coords {
+		c, err := r.readCoordinate()
+		if err != nil {
+			return nil, err
+		}
+		coords[i] = c
+	}
+	return coords, nil
+}
+
+// readHeader reads the byte-order flag and type word shared by every WKB
+// (and EWKB) geometry, and returns the geometry type code plus SRID (0 if
+// absent). It also sets r.order for the remainder of this geometry.
+func (r *wkbReader) readHeader() (geomType int, srid int, hasZ bool, err error) {
+	order, err := r.readByte()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	switch order {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return 0, 0, false, fmt.Errorf("geometry: %w: invalid WKB byte order %d", ErrMalformed, order)
+	}
+
+	rawType, err := r.readUint32()
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	if rawType&ewkbZFlag != 0 {
+		hasZ = true
+		rawType &^= ewkbZFlag
+	}
+	if rawType&ewkbSRIDFlag != 0 {
+		s, err := r.readUint32()
+		if err != nil {
+			return 0, 0, false, err
+		}
+		srid = int(s)
+		rawType &^= ewkbSRIDFlag
+	}
+	return int(rawType), srid, hasZ, nil
+}
+
+func (r *wkbReader) readGeometry() (Geometry, error) {
+	geomType, srid, hasZ, err := r.readHeader()
+	if err != nil {
+		return nil, err
+	}
+	if hasZ {
+		return r.readGeometryZ(geomType, srid)
+	}
+
+	var geom Geometry
+	switch geomType {
+	case wkbPoint:
+		c, err := r.readCoordinate()
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(c.X) && math.IsNaN(c.Y) {
+			geom = NewEmptyPoint()
+		} else {
+			geom = NewPoint(c.X, c.Y)
+		}
+	case wkbLineString:
+		coords, err := r.readCoordinateSequence()
+		if err != nil {
+			return nil, err
+		}
+		geom = NewLineString(coords)
+	case wkbPolygon:
+		rings, err := r.readRingSequence()
+		if err != nil {
+			return nil, err
+		}
+		if len(rings) == 0 {
+			geom = NewPolygon(nil, nil)
+		} else {
+			geom = NewPolygon(rings[0], rings[1:])
+		}
+	case wkbMultiPoint:
+		n, err := r.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		points := make([]*Point, n)
+		for i := range points {
+			sub, err := r.readGeometry()
+			if err != nil {
+				return nil, err
+			}
+			pt, ok := sub.(*Point)
+			if !ok {
+				return nil, fmt.Errorf("geometry: %w: MultiPoint member is not a Point", ErrMalformed)
+			}
+			points[i] = pt
+		}
+		geom = NewMultiPoint(points)
+	case wkbMultiLineString:
+		n, err := r.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		lines := make([]*LineString, n)
+		for i := range lines {
+			sub, err := r.readGeometry()
+			if err != nil {
+				return nil, err
+			}
+			ls, ok := sub.(*LineString)
+			if !ok {
+				return nil, fmt.Errorf("geometry: %w: MultiLineString member is not a LineString", ErrMalformed)
+			}
+			lines[i] = ls
+		}
+		geom = NewMultiLineString(lines)
+	case wkbMultiPolygon:
+		n, err := r.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		polys := make([]*Polygon, n)
+		for i := range polys {
+			sub, err := r.readGeometry()
+			if err != nil {
+				return nil, err
+			}
+			pg, ok := sub.(*Polygon)
+			if !ok {
+				return nil, fmt.Errorf("geometry: %w: MultiPolygon member is not a Polygon", ErrMalformed)
+			}
+			polys[i] = pg
+		}
+		geom = NewMultiPolygon(polys)
+	case wkbGeometryCollection:
+		n, err := r.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		geoms := make([]Geometry, n)
+		for i := range geoms {
+			sub, err := r.readGeometry()
+			if err != nil {
+				return nil, err
+			}
+			geoms[i] = sub
+		}
+		geom = NewGeometryCollection(geoms)
+	default:
+		return nil, fmt.Errorf("geometry: %w: unknown WKB type code %d", ErrMalformed, geomType)
+	}
+
+	setSRID(geom, srid)
+	return geom, nil
+}
+
+func (r *wkbReader) readRingSequence() ([][]Coordinate, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	rings := make([][]Coordinate, n)
+	for i := range rings {
+		ring, err := r.readCoordinateSequence()
+		if err != nil {
+			return nil, err
+		}
+		rings[i] = ring
+	}
+	return rings, nil
+}
+
+// --- AsBinary ---
+//
+// Output is always little-endian (NDR) WKB, matching what PostGIS and
+// most other producers emit by default. EWKB's SRID flag/word is written
+// whenever the geometry carries a non-zero SRID.
+
+type wkbWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *wkbWriter) writeByte(b byte) { w.buf.WriteByte(b) }
+
+func (w *wkbWriter) writeUint32(v uint32) {
+	var tmp [4]byte
+	binary.LittleEndian.PutUint32(tmp[:], v)
+	w.buf.Write(tmp[:])
+}
+
+func (w *wkbWriter) writeFloat64(v float64) {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], math.Float64bits(v))
+	w.buf.Write(tmp[:])
+}
+
+func (w *wkbWriter) writeCoordinate(c Coordinate) {
+	w.writeFloat64(c.X)
+	w.writeFloat64(c.Y)
+}
+
+func (w *wkbWriter) writeCoordinateSequence(coords []Coordinate) {
+	w.writeUint32(uint32(len(coords)))
+	for _, c := range coords {
+		w.writeCoordinate(c)
+	}
+}
+
+func (w *wkbWriter) writeHeader(geomType uint32, srid int) {
+	w.writeByte(1) // little-endian
+	if srid != 0 {
+		w.writeUint32(geomType | ewkbSRIDFlag)
+		w.writeUint32(uint32(srid))
+		return
+	}
+	w.writeUint32(geomType)
+}
+
+func (p *Point) AsBinary() ([]byte, error) {
+	w := &wkbWriter{}
+	w.writeHeader(wkbPoint, p.SRID)
+	if p.Empty {
+		w.writeFloat64(math.NaN())
+		w.writeFloat64(math.NaN())
+	} else {
+		w.writeCoordinate(p.Coordinate)
+	}
+	return w.buf.Bytes(), nil
+}
+
+func (l *LineString) AsBinary() ([]byte, error) {
+	w := &wkbWriter{}
+	w.writeHeader(wkbLineString, l.SRID)
+	w.writeCoordinateSequence(l.Points)
+	return w.buf.Bytes(), nil
+}
+
+func (pg *Polygon) AsBinary() ([]byte, error) {
+	w := &wkbWriter{}
+	w.writeHeader(wkbPolygon, pg.SRID)
+	if len(pg.Shell) == 0 {
+		w.writeUint32(0)
+		return w.buf.Bytes(), nil
+	}
+	rings := append([][]Coordinate{pg.Shell}, pg.Holes...)
+	w.writeUint32(uint32(len(rings)))
+	for _, ring := range rings {
+		w.writeCoordinateSequence(ring)
+	}
+	return w.buf.Bytes(), nil
+}
+
+func (mp *MultiPoint) AsBinary() ([]byte, error) {
+	w := &wkbWriter{}
+	w.writeHeader(wkbMultiPoint, mp.SRID)
+	w.writeUint32(uint32(len(mp.Points)))
+	for _, pt := range mp.Points {
+		sub, err := pt.AsBinary()
+		if err != nil {
+			return nil, err
+		}
+		w.buf.Write(sub)
+	}
+	return w.buf.Bytes(), nil
+}
+
+func (ml *MultiLineString) AsBinary() ([]byte, error) {
+	w := &wkbWriter{}
+	w.writeHeader(wkbMultiLineString, ml.SRID)
+	w.writeUint32(uint32(len(ml.Lines)))
+	for _, l := range ml.Lines {
+		sub, err := l.AsBinary()
+		if err != nil {
+			return nil, err
+		}
+		w.buf.Write(sub)
+	}
+	return w.buf.Bytes(), nil
+}
+
+func (mp *MultiPolygon) AsBinary() ([]byte, error) {
+	w := &wkbWriter{}
+	w.writeHeader(wkbMultiPolygon, mp.SRID)
+	w.writeUint32(uint32(len(mp.Polygons)))
+	for _, pg := range mp.Polygons {
+		sub, err := pg.AsBinary()
+		if err != nil {
+			return nil, err
+		}
+		w.buf.Write(sub)
+	}
+	return w.buf.Bytes(), nil
+}
+
+func (gc *GeometryCollection) AsBinary() ([]byte, error) {
+	w := &wkbWriter{}
+	w.writeHeader(wkbGeometryCollection, gc.SRID)
+	w.writeUint32(uint32(len(gc.Geometries)))
+	for _, g := range gc.Geometries {
+		sub, err := g.AsBinary()
+		if err != nil {
+			return nil, err
+		}
+		w.buf.Write(sub)
+	}
+	return w.buf.Bytes(), nil
+}
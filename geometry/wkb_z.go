@@ -0,0 +1,149 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+func (r *wkbReader) readCoordinateXYZ() (CoordinateXYZ, error) {
+	x, err := r.readFloat64()
+	if err != nil {
+		return CoordinateXYZ{}, err
+	}
+	y, err := r.readFloat64()
+	if err != nil {
+		return CoordinateXYZ{}, err
+	}
+	z, err := r.readFloat64()
+	if err != nil {
+		return CoordinateXYZ{}, err
+	}
+	return CoordinateXYZ{X: x, Y: y, Z: z}, nil
+}
+
+func (r *wkbReader) readCoordinateSequenceXYZ() ([]CoordinateXYZ, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	coords := make([]CoordinateXYZ, n)
+	for i := range coords {
+		c, err := r.readCoordinateXYZ()
+		if err != nil {
+			return nil, err
+		}
+		coords[i] = c
+	}
+	return coords, nil
+}
+
+func (r *wkbReader) readRingSequenceXYZ() ([][]CoordinateXYZ, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	rings := make([][]CoordinateXYZ, n)
+	for i := range rings {
+		ring, err := r.readCoordinateSequenceXYZ()
+		if err != nil {
+			return nil, err
+		}
+		rings[i] = ring
+	}
+	return rings, nil
+}
+
+// readGeometryZ reads the coordinate payload of a WKB/EWKB geometry whose
+// header has already been consumed and flagged as carrying Z ordinates.
+func (r *wkbReader) readGeometryZ(geomType, srid int) (Geometry, error) {
+	var geom Geometry
+	switch geomType {
+	case wkbPoint:
+		c, err := r.readCoordinateXYZ()
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(c.X) && math.IsNaN(c.Y) && math.IsNaN(c.Z) {
+			geom = NewEmptyPointZ()
+		} else {
+			geom = NewPointZ(c.X, c.Y, c.Z)
+		}
+	case wkbLineString:
+		coords, err := r.readCoordinateSequenceXYZ()
+		if err != nil {
+			return nil, err
+		}
+		geom = NewLineStringZ(coords)
+	case wkbPolygon:
+		rings, err := r.readRingSequenceXYZ()
+		if err != nil {
+			return nil, err
+		}
+		if len(rings) == 0 {
+			geom = NewPolygonZ(nil, nil)
+		} else {
+			geom = NewPolygonZ(rings[0], rings[1:])
+		}
+	default:
+		return nil, fmt.Errorf("geometry: %w: Z variant of WKB type code %d is not supported", ErrMalformed, geomType)
+	}
+	setSRID(geom, srid)
+	return geom, nil
+}
+
+func (w *wkbWriter) writeCoordinateXYZ(c CoordinateXYZ) {
+	w.writeFloat64(c.X)
+	w.writeFloat64(c.Y)
+	w.writeFloat64(c.Z)
+}
+
+func (w *wkbWriter) writeCoordinateSequenceXYZ(coords []CoordinateXYZ) {
+	w.writeUint32(uint32(len(coords)))
+	for _, c := range coords {
+		w.writeCoordinateXYZ(c)
+	}
+}
+
+func (w *wkbWriter) writeHeaderZ(geomType uint32, srid int) {
+	w.writeByte(1) // little-endian
+	flagged := geomType | ewkbZFlag
+	if srid != 0 {
+		w.writeUint32(flagged | ewkbSRIDFlag)
+		w.writeUint32(uint32(srid))
+		return
+	}
+	w.writeUint32(flagged)
+}
+
+func (p *PointZ) AsBinary() ([]byte, error) {
+	w := &wkbWriter{}
+	w.writeHeaderZ(wkbPoint, p.SRID)
+	if p.Empty {
+		w.writeCoordinateXYZ(CoordinateXYZ{X: math.NaN(), Y: math.NaN(), Z: math.NaN()})
+	} else {
+		w.writeCoordinateXYZ(p.CoordinateXYZ)
+	}
+	return w.buf.Bytes(), nil
+}
+
+func (l *LineStringZ) AsBinary() ([]byte, error) {
+	w := &wkbWriter{}
+	w.writeHeaderZ(wkbLineString, l.SRID)
+	w.writeCoordinateSequenceXYZ(l.Points)
+	return w.buf.Bytes(), nil
+}
+
+func (pg *PolygonZ) AsBinary() ([]byte, error) {
+	w := &wkbWriter{}
+	w.writeHeaderZ(wkbPolygon, pg.SRID)
+	if len(pg.Shell) == 0 {
+		w.writeUint32(0)
+		return w.buf.Bytes(), nil
+	}
+	rings := append([][]CoordinateXYZ{pg.Shell}, pg.Holes...)
+	w.writeUint32(uint32(len(rings)))
+	for _, ring := range rings {
+		w.writeCoordinateSequenceXYZ(ring)
+	}
+	return w.buf.Bytes(), nil
+}
@@ -0,0 +1,493 @@
+package geometry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FromWKT parses a Well-Known Text (or Extended WKT, i.e. "SRID=4326;...")
+// string into the matching concrete Geometry implementation.
+func FromWKT(s string) (Geometry, error) {
+	s = strings.TrimSpace(s)
+
+	srid := 0
+	if strings.HasPrefix(strings.ToUpper(s), "SRID=") {
+		parts := strings.SplitN(s, ";", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("geometry: %w: missing ';' after SRID", ErrMalformed)
+		}
+		id, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(parts[0], "SRID=")))
+		if err != nil {
+			return nil, fmt.Errorf("geometry: %w: invalid SRID: %v", ErrMalformed, err)
+		}
+		srid, s = id, strings.TrimSpace(parts[1])
+	}
+
+	p := &wktParser{tokens: tokenizeWKT(s)}
+	geom, err := p.parseGeometry()
+	if err != nil {
+		return nil, err
+	}
+	if !p.atEnd() {
+		return nil, fmt.Errorf("geometry: %w: unexpected trailing input", ErrMalformed)
+	}
+	setSRID(geom, srid)
+	return geom, nil
+}
+
+func setSRID(g Geometry, srid int) {
+	switch v := g.(type) {
+	case *Point:
+		v.SRID = srid
+	case *LineString:
+		v.SRID = srid
+	case *Polygon:
+		v.SRID = srid
+	case *MultiPoint:
+		v.SRID = srid
+	case *MultiLineString:
+		v.SRID = srid
+	case *MultiPolygon:
+		v.SRID = srid
+	case *GeometryCollection:
+		v.SRID = srid
+	case *PointZ:
+		v.SRID = srid
+	case *LineStringZ:
+		v.SRID = srid
+	case *PolygonZ:
+		v.SRID = srid
+	}
+}
+
+// tokenizeWKT splits WKT source into words, numbers, parens and commas.
+func tokenizeWKT(s string) []string {
+	var tokens []string
+	var buf strings.Builder
+	flush := func() {
+		if buf.Len() > 0 {
+			tokens = append(tokens, buf.String())
+			buf.Reset()
+		}
+	}
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == ',':
+			flush()
+			tokens = append(tokens, string(r))
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			flush()
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+type wktParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *wktParser) atEnd() bool { return p.pos >= len(p.tokens) }
+
+func (p *wktParser) peek() (string, bool) {
+	if p.atEnd() {
+		return "", false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *wktParser) next() (string, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return "", fmt.Errorf("geometry: %w: unexpected end of input", ErrMalformed)
+	}
+	p.pos++
+	return tok, nil
+}
+
+func (p *wktParser) expect(tok string) error {
+	got, err := p.next()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(got, tok) {
+		return fmt.Errorf("geometry: %w: expected %q, got %q", ErrMalformed, tok, got)
+	}
+	return nil
+}
+
+func (p *wktParser) parseGeometry() (Geometry, error) {
+	tok, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	typeName, is3D := p.splitZSuffix(tok)
+	switch typeName {
+	case "POINT":
+		if is3D {
+			return p.parsePointZ()
+		}
+		return p.parsePoint()
+	case "LINESTRING":
+		if is3D {
+			return p.parseLineStringZ()
+		}
+		return p.parseLineString()
+	case "POLYGON":
+		if is3D {
+			return p.parsePolygonZ()
+		}
+		return p.parsePolygon()
+	case "MULTIPOINT":
+		return p.parseMultiPoint()
+	case "MULTILINESTRING":
+		return p.parseMultiLineString()
+	case "MULTIPOLYGON":
+		return p.parseMultiPolygon()
+	case "GEOMETRYCOLLECTION":
+		return p.parseGeometryCollection()
+	default:
+		return nil, fmt.Errorf("geometry: %w: unknown geometry type %q", ErrMalformed, tok)
+	}
+}
+
+func (p *wktParser) isEmpty() bool {
+	tok, ok := p.peek()
+	if ok && strings.EqualFold(tok, "EMPTY") {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) parsePoint() (Geometry, error) {
+	if p.isEmpty() {
+		return NewEmptyPoint(), nil
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	c, err := p.parseCoordinate()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return NewPoint(c.X, c.Y), nil
+}
+
+func (p *wktParser) parseCoordinate() (Coordinate, error) {
+	xTok, err := p.next()
+	if err != nil {
+		return Coordinate{}, err
+	}
+	yTok, err := p.next()
+	if err != nil {
+		return Coordinate{}, err
+	}
+	x, err := strconv.ParseFloat(xTok, 64)
+	if err != nil {
+		return Coordinate{}, fmt.Errorf("geometry: %w: invalid X ordinate %q", ErrMalformed, xTok)
+	}
+	y, err := strconv.ParseFloat(yTok, 64)
+	if err != nil {
+		return Coordinate{}, fmt.Errorf("geometry: %w: invalid Y ordinate %q", ErrMalformed, yTok)
+	}
+	return Coordinate{X: x, Y: y}, nil
+}
+
+// parseCoordinateSequence parses "(x y, x y, ...)" including the parens.
+func (p *wktParser) parseCoordinateSequence() ([]Coordinate, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var coords []Coordinate
+	for {
+		c, err := p.parseCoordinate()
+		if err != nil {
+			return nil, err
+		}
+		coords = append(coords, c)
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok == ")" {
+			break
+		}
+		if tok != "," {
+			return nil, fmt.Errorf("geometry: %w: expected ',' or ')', got %q", ErrMalformed, tok)
+		}
+	}
+	return coords, nil
+}
+
+func (p *wktParser) parseLineString() (Geometry, error) {
+	if p.isEmpty() {
+		return NewLineString(nil), nil
+	}
+	coords, err := p.parseCoordinateSequence()
+	if err != nil {
+		return nil, err
+	}
+	return NewLineString(coords), nil
+}
+
+// parseRingSequence parses "((ring), (ring), ...)" including the outer parens.
+func (p *wktParser) parseRingSequence() ([][]Coordinate, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var rings [][]Coordinate
+	for {
+		ring, err := p.parseCoordinateSequence()
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, ring)
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok == ")" {
+			break
+		}
+		if tok != "," {
+			return nil, fmt.Errorf("geometry: %w: expected ',' or ')', got %q", ErrMalformed, tok)
+		}
+	}
+	return rings, nil
+}
+
+func (p *wktParser) parsePolygon() (Geometry, error) {
+	if p.isEmpty() {
+		return NewPolygon(nil, nil), nil
+	}
+	rings, err := p.parseRingSequence()
+	if err != nil {
+		return nil, err
+	}
+	if len(rings) == 0 {
+		return NewPolygon(nil, nil), nil
+	}
+	return NewPolygon(rings[0], rings[1:]), nil
+}
+
+func (p *wktParser) parseMultiPoint() (Geometry, error) {
+	if p.isEmpty() {
+		return NewMultiPoint(nil), nil
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var points []*Point
+	for {
+		// MULTIPOINT allows both "(x y)" and bare "x y" members per the spec.
+		var c Coordinate
+		var err error
+		if tok, ok := p.peek(); ok && tok == "(" {
+			p.pos++
+			c, err = p.parseCoordinate()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+		} else {
+			c, err = p.parseCoordinate()
+			if err != nil {
+				return nil, err
+			}
+		}
+		points = append(points, NewPoint(c.X, c.Y))
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok == ")" {
+			break
+		}
+		if tok != "," {
+			return nil, fmt.Errorf("geometry: %w: expected ',' or ')', got %q", ErrMalformed, tok)
+		}
+	}
+	return NewMultiPoint(points), nil
+}
+
+func (p *wktParser) parseMultiLineString() (Geometry, error) {
+	if p.isEmpty() {
+		return NewMultiLineString(nil), nil
+	}
+	rings, err := p.parseRingSequence()
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]*LineString, len(rings))
+	for i, r := range rings {
+		lines[i] = NewLineString(r)
+	}
+	return NewMultiLineString(lines), nil
+}
+
+func (p *wktParser) parseMultiPolygon() (Geometry, error) {
+	if p.isEmpty() {
+		return NewMultiPolygon(nil), nil
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var polys []*Polygon
+	for {
+		rings, err := p.parseRingSequence()
+		if err != nil {
+			return nil, err
+		}
+		if len(rings) == 0 {
+			polys = append(polys, NewPolygon(nil, nil))
+		} else {
+			polys = append(polys, NewPolygon(rings[0], rings[1:]))
+		}
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok == ")" {
+			break
+		}
+		if tok != "," {
+			return nil, fmt.Errorf("geometry: %w: expected ',' or ')', got %q", ErrMalformed, tok)
+		}
+	}
+	return NewMultiPolygon(polys), nil
+}
+
+func (p *wktParser) parseGeometryCollection() (Geometry, error) {
+	if p.isEmpty() {
+		return NewGeometryCollection(nil), nil
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var geoms []Geometry
+	for {
+		g, err := p.parseGeometry()
+		if err != nil {
+			return nil, err
+		}
+		geoms = append(geoms, g)
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok == ")" {
+			break
+		}
+		if tok != "," {
+			return nil, fmt.Errorf("geometry: %w: expected ',' or ')', got %q", ErrMalformed, tok)
+		}
+	}
+	return NewGeometryCollection(geoms), nil
+}
+
+// --- AsText ---
+
+func formatOrdinate(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func formatCoordinate(c Coordinate) string {
+	return formatOrdinate(c.X) + " " + formatOrdinate(c.Y)
+}
+
+func formatCoordinateSequence(coords []Coordinate) string {
+	parts := make([]string, len(coords))
+	for i, c := range coords {
+		parts[i] = formatCoordinate(c)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func formatRingSequence(rings [][]Coordinate) string {
+	parts := make([]string, len(rings))
+	for i, r := range rings {
+		parts[i] = formatCoordinateSequence(r)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func (p *Point) AsText() (string, error) {
+	if p.Empty {
+		return "POINT EMPTY", nil
+	}
+	return "POINT (" + formatCoordinate(p.Coordinate) + ")", nil
+}
+
+func (l *LineString) AsText() (string, error) {
+	if len(l.Points) == 0 {
+		return "LINESTRING EMPTY", nil
+	}
+	return "LINESTRING " + formatCoordinateSequence(l.Points), nil
+}
+
+func (pg *Polygon) AsText() (string, error) {
+	if len(pg.Shell) == 0 {
+		return "POLYGON EMPTY", nil
+	}
+	rings := append([][]Coordinate{pg.Shell}, pg.Holes...)
+	return "POLYGON " + formatRingSequence(rings), nil
+}
+
+func (mp *MultiPoint) AsText() (string, error) {
+	if len(mp.Points) == 0 {
+		return "MULTIPOINT EMPTY", nil
+	}
+	parts := make([]string, len(mp.Points))
+	for i, pt := range mp.Points {
+		parts[i] = "(" + formatCoordinate(pt.Coordinate) + ")"
+	}
+	return "MULTIPOINT (" + strings.Join(parts, ", ") + ")", nil
+}
+
+func (ml *MultiLineString) AsText() (string, error) {
+	if len(ml.Lines) == 0 {
+		return "MULTILINESTRING EMPTY", nil
+	}
+	rings := make([][]Coordinate, len(ml.Lines))
+	for i, l := range ml.Lines {
+		rings[i] = l.Points
+	}
+	return "MULTILINESTRING " + formatRingSequence(rings), nil
+}
+
+func (mp *MultiPolygon) AsText() (string, error) {
+	if len(mp.Polygons) == 0 {
+		return "MULTIPOLYGON EMPTY", nil
+	}
+	parts := make([]string, len(mp.Polygons))
+	for i, pg := range mp.Polygons {
+		rings := append([][]Coordinate{pg.Shell}, pg.Holes...)
+		parts[i] = formatRingSequence(rings)
+	}
+	return "MULTIPOLYGON (" + strings.Join(parts, ", ") + ")", nil
+}
+
+func (gc *GeometryCollection) AsText() (string, error) {
+	if len(gc.Geometries) == 0 {
+		return "GEOMETRYCOLLECTION EMPTY", nil
+	}
+	parts := make([]string, len(gc.Geometries))
+	for i, g := range gc.Geometries {
+		wkt, err := g.AsText()
+		if err != nil {
+			return "", err
+		}
+		parts[i] = wkt
+	}
+	return "GEOMETRYCOLLECTION (" + strings.Join(parts, ", ") + ")", nil
+}
@@ -0,0 +1,112 @@
+package geometry
+
+import "testing"
+
+func TestWKTRoundTrip(t *testing.T) {
+	cases := []string{
+		"POINT (1 2)",
+		"POINT EMPTY",
+		"LINESTRING (0 0, 1 1, 2 2)",
+		"LINESTRING EMPTY",
+		"POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))",
+		"POLYGON EMPTY",
+		"MULTIPOINT ((0 0), (1 1))",
+		"MULTILINESTRING ((0 0, 1 1), (2 2, 3 3))",
+		"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)), ((2 2, 3 2, 3 3, 2 3, 2 2)))",
+		"GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (0 0, 1 1))",
+	}
+	for _, wkt := range cases {
+		g, err := FromWKT(wkt)
+		if err != nil {
+			t.Fatalf("FromWKT(%q): %v", wkt, err)
+		}
+		got, err := g.AsText()
+		if err != nil {
+			t.Fatalf("AsText() for %q: %v", wkt, err)
+		}
+		if got != wkt {
+			t.Errorf("round trip mismatch: got %q, want %q", got, wkt)
+		}
+	}
+}
+
+func TestWKTWithSRID(t *testing.T) {
+	g, err := FromWKT("SRID=4326;POINT (1 2)")
+	if err != nil {
+		t.Fatalf("FromWKT: %v", err)
+	}
+	pt, ok := g.(*Point)
+	if !ok {
+		t.Fatalf("expected *Point, got %T", g)
+	}
+	if pt.SRID != 4326 {
+		t.Errorf("SRID = %d, want 4326", pt.SRID)
+	}
+}
+
+func TestFromWKTMalformed(t *testing.T) {
+	cases := []string{
+		"",
+		"POINT (1)",
+		"NOTAGEOM (1 2)",
+		"POINT (1 2) trailing",
+	}
+	for _, wkt := range cases {
+		if _, err := FromWKT(wkt); err == nil {
+			t.Errorf("FromWKT(%q): expected error, got nil", wkt)
+		}
+	}
+}
+
+func TestWKBRoundTrip(t *testing.T) {
+	cases := []string{
+		"POINT (1 2)",
+		"POINT EMPTY",
+		"LINESTRING (0 0, 1 1, 2 2)",
+		"POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))",
+		"MULTIPOINT ((0 0), (1 1))",
+		"MULTILINESTRING ((0 0, 1 1), (2 2, 3 3))",
+		"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)))",
+		"GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (0 0, 1 1))",
+	}
+	for _, wkt := range cases {
+		g, err := FromWKT(wkt)
+		if err != nil {
+			t.Fatalf("FromWKT(%q): %v", wkt, err)
+		}
+		wkb, err := g.AsBinary()
+		if err != nil {
+			t.Fatalf("AsBinary() for %q: %v", wkt, err)
+		}
+		back, err := FromWKB(wkb)
+		if err != nil {
+			t.Fatalf("FromWKB() for %q: %v", wkt, err)
+		}
+		gotText, err := back.AsText()
+		if err != nil {
+			t.Fatalf("AsText() after WKB round trip for %q: %v", wkt, err)
+		}
+		if gotText != wkt {
+			t.Errorf("WKB round trip mismatch: got %q, want %q", gotText, wkt)
+		}
+	}
+}
+
+func TestWKBEmptyPointZRoundTrip(t *testing.T) {
+	pz := NewEmptyPointZ()
+	wkb, err := pz.AsBinary()
+	if err != nil {
+		t.Fatalf("AsBinary: %v", err)
+	}
+	back, err := FromWKB(wkb)
+	if err != nil {
+		t.Fatalf("FromWKB: %v", err)
+	}
+	got, ok := back.(*PointZ)
+	if !ok {
+		t.Fatalf("expected *PointZ, got %T", back)
+	}
+	if !got.Empty {
+		t.Errorf("expected round-tripped PointZ to still be Empty")
+	}
+}
@@ -0,0 +1,180 @@
+package geometry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// splitZSuffix recognizes both the ISO SQL/MM form ("POINT Z (...)", a
+// separate "Z" token) and the common pasted-together form ("POINTZ(...)")
+// and returns the bare type name plus whether a Z qualifier was found.
+func (p *wktParser) splitZSuffix(tok string) (string, bool) {
+	upper := strings.ToUpper(tok)
+	switch upper {
+	case "POINTZ":
+		return "POINT", true
+	case "LINESTRINGZ":
+		return "LINESTRING", true
+	case "POLYGONZ":
+		return "POLYGON", true
+	}
+	if next, ok := p.peek(); ok && strings.EqualFold(next, "Z") {
+		switch upper {
+		case "POINT", "LINESTRING", "POLYGON":
+			p.pos++
+			return upper, true
+		}
+	}
+	return upper, false
+}
+
+func (p *wktParser) parseCoordinateXYZ() (CoordinateXYZ, error) {
+	c, err := p.parseCoordinate()
+	if err != nil {
+		return CoordinateXYZ{}, err
+	}
+	zTok, err := p.next()
+	if err != nil {
+		return CoordinateXYZ{}, err
+	}
+	z, err := strconv.ParseFloat(zTok, 64)
+	if err != nil {
+		return CoordinateXYZ{}, fmt.Errorf("geometry: %w: invalid Z ordinate %q", ErrMalformed, zTok)
+	}
+	return CoordinateXYZ{X: c.X, Y: c.Y, Z: z}, nil
+}
+
+func (p *wktParser) parseCoordinateSequenceXYZ() ([]CoordinateXYZ, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var coords []CoordinateXYZ
+	for {
+		c, err := p.parseCoordinateXYZ()
+		if err != nil {
+			return nil, err
+		}
+		coords = append(coords, c)
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok == ")" {
+			break
+		}
+		if tok != "," {
+			return nil, fmt.Errorf("geometry: %w: expected ',' or ')', got %q", ErrMalformed, tok)
+		}
+	}
+	return coords, nil
+}
+
+func (p *wktParser) parseRingSequenceXYZ() ([][]CoordinateXYZ, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var rings [][]CoordinateXYZ
+	for {
+		ring, err := p.parseCoordinateSequenceXYZ()
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, ring)
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok == ")" {
+			break
+		}
+		if tok != "," {
+			return nil, fmt.Errorf("geometry: %w: expected ',' or ')', got %q", ErrMalformed, tok)
+		}
+	}
+	return rings, nil
+}
+
+func (p *wktParser) parsePointZ() (Geometry, error) {
+	if p.isEmpty() {
+		return NewEmptyPointZ(), nil
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	c, err := p.parseCoordinateXYZ()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return NewPointZ(c.X, c.Y, c.Z), nil
+}
+
+func (p *wktParser) parseLineStringZ() (Geometry, error) {
+	if p.isEmpty() {
+		return NewLineStringZ(nil), nil
+	}
+	coords, err := p.parseCoordinateSequenceXYZ()
+	if err != nil {
+		return nil, err
+	}
+	return NewLineStringZ(coords), nil
+}
+
+func (p *wktParser) parsePolygonZ() (Geometry, error) {
+	if p.isEmpty() {
+		return NewPolygonZ(nil, nil), nil
+	}
+	rings, err := p.parseRingSequenceXYZ()
+	if err != nil {
+		return nil, err
+	}
+	if len(rings) == 0 {
+		return NewPolygonZ(nil, nil), nil
+	}
+	return NewPolygonZ(rings[0], rings[1:]), nil
+}
+
+func formatCoordinateXYZ(c CoordinateXYZ) string {
+	return formatOrdinate(c.X) + " " + formatOrdinate(c.Y) + " " + formatOrdinate(c.Z)
+}
+
+func formatCoordinateSequenceXYZ(coords []CoordinateXYZ) string {
+	parts := make([]string, len(coords))
+	for i, c := range coords {
+		parts[i] = formatCoordinateXYZ(c)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func formatRingSequenceXYZ(rings [][]CoordinateXYZ) string {
+	parts := make([]string, len(rings))
+	for i, r := range rings {
+		parts[i] = formatCoordinateSequenceXYZ(r)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func (p *PointZ) AsText() (string, error) {
+	if p.Empty {
+		return "POINT Z EMPTY", nil
+	}
+	return "POINT Z (" + formatCoordinateXYZ(p.CoordinateXYZ) + ")", nil
+}
+
+func (l *LineStringZ) AsText() (string, error) {
+	if len(l.Points) == 0 {
+		return "LINESTRING Z EMPTY", nil
+	}
+	return "LINESTRING Z " + formatCoordinateSequenceXYZ(l.Points), nil
+}
+
+func (pg *PolygonZ) AsText() (string, error) {
+	if len(pg.Shell) == 0 {
+		return "POLYGON Z EMPTY", nil
+	}
+	rings := append([][]CoordinateXYZ{pg.Shell}, pg.Holes...)
+	return "POLYGON Z " + formatRingSequenceXYZ(rings), nil
+}
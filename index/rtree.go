@@ -0,0 +1,306 @@
+// Package index provides spatial indexing structures used to speed up
+// bulk geometry predicate queries (e.g. geometry.STIntersectionMap)
+// beyond the O(N*M) of comparing every pair directly.
+//
+// It deliberately does not import the geometry package: Envelope here is
+// a plain bounding box, and Bounded is the minimal interface an indexed
+// item must satisfy, so that geometry (which does import index for its
+// bulk operators) never forms an import cycle with it.
+package index
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// Envelope is an axis-aligned bounding box.
+type Envelope struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Intersects reports whether two envelopes overlap or touch.
+func (e Envelope) Intersects(o Envelope) bool {
+	return e.MinX <= o.MaxX && e.MaxX >= o.MinX && e.MinY <= o.MaxY && e.MaxY >= o.MinY
+}
+
+// union returns the smallest envelope containing both e and o.
+func (e Envelope) union(o Envelope) Envelope {
+	return Envelope{
+		MinX: math.Min(e.MinX, o.MinX),
+		MinY: math.Min(e.MinY, o.MinY),
+		MaxX: math.Max(e.MaxX, o.MaxX),
+		MaxY: math.Max(e.MaxY, o.MaxY),
+	}
+}
+
+func (e Envelope) centerX() float64 { return (e.MinX + e.MaxX) / 2 }
+func (e Envelope) centerY() float64 { return (e.MinY + e.MaxY) / 2 }
+
+// distanceTo returns the minimum Euclidean distance between e and o (0 if
+// they intersect).
+func (e Envelope) distanceTo(o Envelope) float64 {
+	dx := math.Max(0, math.Max(e.MinX-o.MaxX, o.MinX-e.MaxX))
+	dy := math.Max(0, math.Max(e.MinY-o.MaxY, o.MinY-e.MaxY))
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// Bounded is satisfied by anything that can be bulk-loaded into an
+// STRtree via Build; callers outside this package typically wrap a
+// geometry.Geometry plus its precomputed geometry.Bounds in a small
+// adapter type.
+type Bounded interface {
+	Bounds() Envelope
+}
+
+// defaultNodeCapacity is the maximum number of children/entries per node.
+const defaultNodeCapacity = 16
+
+type entry struct {
+	env  Envelope
+	item interface{}
+}
+
+type rtreeNode struct {
+	env      Envelope
+	leaf     bool
+	entries  []entry
+	children []*rtreeNode
+}
+
+// STRtree is a bulk-loadable R-tree keyed on Envelope, packed via the
+// Sort-Tile-Recursive (STR) algorithm. It is read-heavy by design: Insert
+// and Build only stage entries, and the tree itself is (re)packed lazily
+// the next time Query or NearestNeighbor is called.
+type STRtree struct {
+	nodeCapacity int
+	entries      []entry
+	root         *rtreeNode
+	dirty        bool
+}
+
+// NewSTRtree returns an empty STRtree using the default node capacity.
+func NewSTRtree() *STRtree {
+	return &STRtree{nodeCapacity: defaultNodeCapacity}
+}
+
+// Insert stages item, keyed on env, for the tree. The tree is not
+// repacked until the next Query/QueryVisit/NearestNeighbor call.
+func (t *STRtree) Insert(env Envelope, item interface{}) {
+	t.entries = append(t.entries, entry{env: env, item: item})
+	t.dirty = true
+}
+
+// Build discards any previously staged entries and stages one per item,
+// keyed on its own Bounds(). Like Insert, the tree is not packed until
+// the next query.
+func (t *STRtree) Build(items []Bounded) {
+	t.entries = make([]entry, len(items))
+	for i, it := range items {
+		t.entries[i] = entry{env: it.Bounds(), item: it}
+	}
+	t.dirty = true
+}
+
+func (t *STRtree) ensureBuilt() {
+	if !t.dirty {
+		return
+	}
+	t.root = strBuild(t.entries, t.nodeCapacity)
+	t.dirty = false
+}
+
+// Query returns every staged item whose envelope intersects env.
+func (t *STRtree) Query(env Envelope) []interface{} {
+	var out []interface{}
+	t.QueryVisit(env, func(item interface{}) bool {
+		out = append(out, item)
+		return true
+	})
+	return out
+}
+
+// QueryVisit calls visit for every staged item whose envelope intersects
+// env, in no particular order, stopping early as soon as visit returns
+// false.
+func (t *STRtree) QueryVisit(env Envelope, visit func(item interface{}) bool) {
+	t.ensureBuilt()
+	if t.root == nil {
+		return
+	}
+	queryNode(t.root, env, visit)
+}
+
+// queryNode returns false once visit has asked to stop, so callers
+// higher up the recursion stop descending into siblings too.
+func queryNode(n *rtreeNode, env Envelope, visit func(interface{}) bool) bool {
+	if !n.env.Intersects(env) {
+		return true
+	}
+	if n.leaf {
+		for _, e := range n.entries {
+			if e.env.Intersects(env) {
+				if !visit(e.item) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	for _, c := range n.children {
+		if !queryNode(c, env, visit) {
+			return false
+		}
+	}
+	return true
+}
+
+// nnQueueItem is either an internal node or a leaf entry, ordered by the
+// distance its own envelope puts between it and the query envelope.
+type nnQueueItem struct {
+	dist  float64
+	node  *rtreeNode
+	entry *entry
+}
+
+type nnQueue []nnQueueItem
+
+func (q nnQueue) Len() int            { return len(q) }
+func (q nnQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q nnQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *nnQueue) Push(x interface{}) { *q = append(*q, x.(nnQueueItem)) }
+func (q *nnQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
+
+// NearestNeighbor returns up to k staged items ordered by the distance
+// from their envelope to env (0 for any that intersect env). It is a
+// best-first branch-and-bound search over the tree: nodes and entries
+// share one priority queue keyed on envelope distance, so a subtree is
+// only expanded once something closer than it might be needed, letting
+// it skip subtrees Query-like code would otherwise have to descend into.
+func (t *STRtree) NearestNeighbor(env Envelope, k int) []interface{} {
+	t.ensureBuilt()
+	if t.root == nil || k <= 0 {
+		return nil
+	}
+
+	q := &nnQueue{{dist: t.root.env.distanceTo(env), node: t.root}}
+	heap.Init(q)
+
+	out := make([]interface{}, 0, k)
+	for q.Len() > 0 && len(out) < k {
+		top := heap.Pop(q).(nnQueueItem)
+		if top.entry != nil {
+			out = append(out, top.entry.item)
+			continue
+		}
+		n := top.node
+		if n.leaf {
+			for i := range n.entries {
+				e := &n.entries[i]
+				heap.Push(q, nnQueueItem{dist: e.env.distanceTo(env), entry: e})
+			}
+			continue
+		}
+		for _, c := range n.children {
+			heap.Push(q, nnQueueItem{dist: c.env.distanceTo(env), node: c})
+		}
+	}
+	return out
+}
+
+// strBuild packs entries into a single-rooted tree via repeated STR
+// tiling: the leaf level is built directly from entries, then each
+// successive level packs the previous one's nodes the same way, until
+// only one node remains.
+func strBuild(entries []entry, nodeCapacity int) *rtreeNode {
+	if len(entries) == 0 {
+		return nil
+	}
+	level := strPackLeaves(entries, nodeCapacity)
+	for len(level) > 1 {
+		level = strPackNodes(level, nodeCapacity)
+	}
+	return level[0]
+}
+
+// strSliceCount returns the number of vertical slices STR should use to
+// tile n items into nodeCapacity-sized groups.
+func strSliceCount(n, nodeCapacity int) int {
+	numLeaves := int(math.Ceil(float64(n) / float64(nodeCapacity)))
+	if numLeaves < 1 {
+		numLeaves = 1
+	}
+	slices := int(math.Ceil(math.Sqrt(float64(numLeaves))))
+	if slices < 1 {
+		slices = 1
+	}
+	return slices
+}
+
+func strPackLeaves(entries []entry, nodeCapacity int) []*rtreeNode {
+	sorted := append([]entry{}, entries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].env.centerX() < sorted[j].env.centerX() })
+
+	sliceSize := strSliceCount(len(sorted), nodeCapacity) * nodeCapacity
+	var nodes []*rtreeNode
+	for start := 0; start < len(sorted); start += sliceSize {
+		end := start + sliceSize
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+		slice := sorted[start:end]
+		sort.Slice(slice, func(i, j int) bool { return slice[i].env.centerY() < slice[j].env.centerY() })
+
+		for i := 0; i < len(slice); i += nodeCapacity {
+			j := i + nodeCapacity
+			if j > len(slice) {
+				j = len(slice)
+			}
+			group := slice[i:j]
+			node := &rtreeNode{leaf: true, entries: append([]entry{}, group...)}
+			node.env = group[0].env
+			for _, e := range group[1:] {
+				node.env = node.env.union(e.env)
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+func strPackNodes(children []*rtreeNode, nodeCapacity int) []*rtreeNode {
+	sorted := append([]*rtreeNode{}, children...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].env.centerX() < sorted[j].env.centerX() })
+
+	sliceSize := strSliceCount(len(sorted), nodeCapacity) * nodeCapacity
+	var nodes []*rtreeNode
+	for start := 0; start < len(sorted); start += sliceSize {
+		end := start + sliceSize
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+		slice := sorted[start:end]
+		sort.Slice(slice, func(i, j int) bool { return slice[i].env.centerY() < slice[j].env.centerY() })
+
+		for i := 0; i < len(slice); i += nodeCapacity {
+			j := i + nodeCapacity
+			if j > len(slice) {
+				j = len(slice)
+			}
+			group := slice[i:j]
+			node := &rtreeNode{children: append([]*rtreeNode{}, group...)}
+			node.env = group[0].env
+			for _, c := range group[1:] {
+				node.env = node.env.union(c.env)
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,99 @@
+package index
+
+import "testing"
+
+type testItem struct {
+	name string
+	env  Envelope
+}
+
+func (t testItem) Bounds() Envelope { return t.env }
+
+func TestSTRtreeQuery(t *testing.T) {
+	items := []Bounded{
+		testItem{"a", Envelope{0, 0, 1, 1}},
+		testItem{"b", Envelope{5, 5, 6, 6}},
+		testItem{"c", Envelope{0.5, 0.5, 1.5, 1.5}},
+	}
+	tree := NewSTRtree()
+	tree.Build(items)
+
+	got := tree.Query(Envelope{0, 0, 1, 1})
+	names := map[string]bool{}
+	for _, g := range got {
+		names[g.(testItem).name] = true
+	}
+	if !names["a"] || !names["c"] {
+		t.Errorf("Query missed expected overlapping items, got %v", got)
+	}
+	if names["b"] {
+		t.Errorf("Query returned non-overlapping item b")
+	}
+}
+
+func TestSTRtreeQueryVisitEarlyStop(t *testing.T) {
+	items := []Bounded{
+		testItem{"a", Envelope{0, 0, 1, 1}},
+		testItem{"b", Envelope{0, 0, 1, 1}},
+		testItem{"c", Envelope{0, 0, 1, 1}},
+	}
+	tree := NewSTRtree()
+	tree.Build(items)
+
+	visited := 0
+	tree.QueryVisit(Envelope{0, 0, 1, 1}, func(item interface{}) bool {
+		visited++
+		return false
+	})
+	if visited != 1 {
+		t.Errorf("QueryVisit visited %d items, want 1 after returning false", visited)
+	}
+}
+
+func TestSTRtreeNearestNeighbor(t *testing.T) {
+	items := []Bounded{
+		testItem{"far", Envelope{100, 100, 101, 101}},
+		testItem{"near", Envelope{1, 1, 2, 2}},
+		testItem{"mid", Envelope{10, 10, 11, 11}},
+	}
+	tree := NewSTRtree()
+	tree.Build(items)
+
+	got := tree.NearestNeighbor(Envelope{0, 0, 0, 0}, 2)
+	if len(got) != 2 {
+		t.Fatalf("NearestNeighbor returned %d items, want 2", len(got))
+	}
+	if got[0].(testItem).name != "near" {
+		t.Errorf("closest item = %q, want %q", got[0].(testItem).name, "near")
+	}
+	if got[1].(testItem).name != "mid" {
+		t.Errorf("second closest item = %q, want %q", got[1].(testItem).name, "mid")
+	}
+}
+
+func TestSTRtreeNearestNeighborCapsAtAvailableItems(t *testing.T) {
+	items := []Bounded{testItem{"only", Envelope{0, 0, 1, 1}}}
+	tree := NewSTRtree()
+	tree.Build(items)
+
+	got := tree.NearestNeighbor(Envelope{0, 0, 0, 0}, 5)
+	if len(got) != 1 {
+		t.Errorf("NearestNeighbor returned %d items, want 1 (fewer than k available)", len(got))
+	}
+}
+
+func TestSTRtreeBulkLoadManyItems(t *testing.T) {
+	var items []Bounded
+	for i := 0; i < 500; i++ {
+		x := float64(i % 50)
+		y := float64(i / 50)
+		items = append(items, testItem{name: "pt", env: Envelope{x, y, x, y}})
+	}
+	tree := NewSTRtree()
+	tree.Build(items)
+
+	got := tree.Query(Envelope{0, 0, 49, 9})
+	if len(got) != 500 {
+		t.Errorf("Query over the full extent returned %d items, want 500", len(got))
+	}
+}